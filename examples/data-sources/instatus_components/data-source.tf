@@ -0,0 +1,11 @@
+# List the components of a page.
+data "instatus_components" "all" {
+  page_id = "PAGE_ID"
+}
+
+# Import all the components of the page (Terraform >= 1.7).
+import {
+  for_each = { for component in data.instatus_components.all.components : component.name => component.import_id }
+  to       = instatus_component.all[each.key]
+  id       = each.value
+}
@@ -0,0 +1,8 @@
+# List the components of a page with `terraform query` (Terraform >= 1.14).
+list "instatus_component" "all" {
+  provider = instatus
+
+  config {
+    page_id = "PAGE_ID"
+  }
+}
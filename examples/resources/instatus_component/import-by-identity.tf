@@ -0,0 +1,7 @@
+import {
+  to = instatus_component.example
+  identity = {
+    page_id = "pageId"
+    id      = "componentId"
+  }
+}
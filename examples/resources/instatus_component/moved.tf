@@ -0,0 +1,4 @@
+moved {
+  from = statuspage_component.example
+  to   = instatus_component.example
+}
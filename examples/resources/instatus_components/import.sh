@@ -0,0 +1,2 @@
+# Import identifier is the page ID, all the components of the page are imported
+terraform import instatus_components.example pageId
@@ -0,0 +1,13 @@
+# Manage all the components of a page at once.
+resource "instatus_components" "example" {
+  page_id = "PAGE_ID"
+  components = {
+    "App" = {
+      description = "Example App"
+    }
+    "API" = {
+      description = "Example API"
+      group_name  = "Backend"
+    }
+  }
+}
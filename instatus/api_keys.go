@@ -0,0 +1,59 @@
+package instatus
+
+import (
+	"io"
+	"net/http"
+	"sync"
+
+	is "github.com/brunoscota/instatus-client-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// apiKeyFailoverClient authenticates requests with one of several API keys,
+// moving on to the next key whenever the API rejects the current one, so key
+// rotations don't break long-running pipelines.
+type apiKeyFailoverClient struct {
+	client is.HTTPClient
+	keys   []string
+
+	mu      sync.Mutex
+	current int
+}
+
+// Do sends the request with the current key, failing over on 401 responses.
+func (c *apiKeyFailoverClient) Do(req *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	start := c.current
+	c.mu.Unlock()
+
+	for i := 0; ; i++ {
+		index := (start + i) % len(c.keys)
+
+		attempt := req.Clone(req.Context())
+		if i > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attempt.Body = body
+		}
+		attempt.Header.Set("Authorization", "Bearer "+c.keys[index])
+
+		resp, err := c.client.Do(attempt)
+		if err != nil || resp.StatusCode != http.StatusUnauthorized || i == len(c.keys)-1 {
+			if err == nil && resp.StatusCode != http.StatusUnauthorized {
+				c.mu.Lock()
+				c.current = index
+				c.mu.Unlock()
+			}
+			return resp, err
+		}
+
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		tflog.Warn(req.Context(), "Instatus API key rejected, failing over to the next key", map[string]interface{}{
+			"key_index": index,
+		})
+	}
+}
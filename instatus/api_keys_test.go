@@ -0,0 +1,64 @@
+package instatus
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// rejectKeys returns a handler answering 401 to the requests authenticated
+// with one of the rejected keys.
+func rejectKeys(rejected ...string) func(req *http.Request) (*http.Response, error) {
+	return func(req *http.Request) (*http.Response, error) {
+		for _, key := range rejected {
+			if req.Header.Get("Authorization") == "Bearer "+key {
+				return newTestResponse(req, http.StatusUnauthorized, nil, `{"message":"invalid key"}`), nil
+			}
+		}
+		return newTestResponse(req, http.StatusOK, nil, "{}"), nil
+	}
+}
+
+func TestAPIKeyFailover(t *testing.T) {
+	client := &handlerClient{handle: rejectKeys("old")}
+	failover := &apiKeyFailoverClient{client: client, keys: []string{"old", "new"}}
+
+	req, err := http.NewRequest(http.MethodPost, defaultAPIEndpoint+"/"+testPageID+"/components", strings.NewReader(`{"name":"API"}`))
+	if err != nil {
+		t.Fatalf("creating request: %v", err)
+	}
+	resp, err := failover.Do(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected the next key to be used, got %v, %v", resp, err)
+	}
+	if len(client.requests) != 2 || client.bodies[1] != `{"name":"API"}` {
+		t.Fatalf("expected the request to be sent again with its body, got %q", client.bodies)
+	}
+
+	// Later requests start with the key that worked.
+	if _, err := failover.Do(newTestRequest(t, http.MethodGet)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(client.requests) != 3 {
+		t.Fatalf("expected a single request with the working key, got %d requests", len(client.requests))
+	}
+	if auth := client.requests[2].Header.Get("Authorization"); auth != "Bearer new" {
+		t.Errorf("expected the new key to be used, got %q", auth)
+	}
+}
+
+func TestAPIKeyFailoverAllKeysRejected(t *testing.T) {
+	client := &handlerClient{handle: rejectKeys("old", "new")}
+	failover := &apiKeyFailoverClient{client: client, keys: []string{"old", "new"}}
+
+	resp, err := failover.Do(newTestRequest(t, http.MethodGet))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected the last rejection to be returned, got status %d", resp.StatusCode)
+	}
+	if len(client.requests) != 2 {
+		t.Errorf("expected every key to be tried once, got %d requests", len(client.requests))
+	}
+}
@@ -0,0 +1,127 @@
+package instatus
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	is "github.com/brunoscota/instatus-client-go"
+)
+
+// cachedResponse is a successful GET response kept by cacheClient.
+type cachedResponse struct {
+	statusCode int
+	header     http.Header
+	body       []byte
+	expires    time.Time
+}
+
+// response returns a fresh copy of the cached response for req.
+func (c *cachedResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        http.StatusText(c.statusCode),
+		StatusCode:    c.statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        c.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(c.body)),
+		ContentLength: int64(len(c.body)),
+		Request:       req,
+	}
+}
+
+// cacheClient serves repeated GET requests from memory for ttl, so a
+// refresh of many resources doesn't fetch identical data over and over.
+// Once an entry expires, or when caching is disabled, responses carrying an
+// ETag or Last-Modified header are revalidated with a conditional request
+// and reused when the API reports them as not modified. Any other request
+// empties the cache, as it may have changed what the cached responses
+// describe.
+type cacheClient struct {
+	client is.HTTPClient
+	ttl    time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*cachedResponse
+}
+
+// Do serves the request from the cache when possible.
+func (c *cacheClient) Do(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		c.mu.Lock()
+		c.entries = nil
+		c.mu.Unlock()
+
+		return c.client.Do(req)
+	}
+
+	key := req.URL.String()
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.response(req), nil
+	}
+
+	if ok {
+		req = req.Clone(req.Context())
+		if etag := entry.header.Get("ETag"); etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		if lastModified := entry.header.Get("Last-Modified"); lastModified != "" {
+			req.Header.Set("If-Modified-Since", lastModified)
+		}
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return resp, err
+	}
+
+	if ok && resp.StatusCode == http.StatusNotModified {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		entry = &cachedResponse{
+			statusCode: entry.statusCode,
+			header:     entry.header,
+			body:       entry.body,
+			expires:    time.Now().Add(c.ttl),
+		}
+	} else {
+		if resp.StatusCode != http.StatusOK {
+			return resp, nil
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		entry = &cachedResponse{
+			statusCode: resp.StatusCode,
+			header:     resp.Header.Clone(),
+			body:       body,
+			expires:    time.Now().Add(c.ttl),
+		}
+
+		// Without a TTL, only responses that can be revalidated are worth keeping.
+		if c.ttl <= 0 && entry.header.Get("ETag") == "" && entry.header.Get("Last-Modified") == "" {
+			return entry.response(req), nil
+		}
+	}
+
+	c.mu.Lock()
+	if c.entries == nil {
+		c.entries = make(map[string]*cachedResponse)
+	}
+	c.entries[key] = entry
+	c.mu.Unlock()
+
+	return entry.response(req), nil
+}
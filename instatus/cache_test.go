@@ -0,0 +1,117 @@
+package instatus
+
+import (
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// readBody returns the body of a response.
+func readBody(t *testing.T, resp *http.Response) string {
+	t.Helper()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("reading body: %v", err)
+	}
+	resp.Body.Close()
+
+	return string(body)
+}
+
+func TestCacheClientServesReadsWithinTTL(t *testing.T) {
+	client := &handlerClient{handle: func(req *http.Request) (*http.Response, error) {
+		return newTestResponse(req, http.StatusOK, nil, `[{"id":"api"}]`), nil
+	}}
+	cache := &cacheClient{client: client, ttl: time.Hour}
+
+	for i := 0; i < 3; i++ {
+		resp, err := cache.Do(newTestRequest(t, http.MethodGet))
+		if err != nil {
+			t.Fatalf("request %d: unexpected error: %v", i, err)
+		}
+		if body := readBody(t, resp); body != `[{"id":"api"}]` {
+			t.Errorf("request %d: expected the cached body, got %q", i, body)
+		}
+	}
+	if len(client.requests) != 1 {
+		t.Errorf("expected a single request to the API, got %d", len(client.requests))
+	}
+}
+
+func TestCacheClientRevalidatesWithETag(t *testing.T) {
+	client := &handlerClient{handle: func(req *http.Request) (*http.Response, error) {
+		if req.Header.Get("If-None-Match") == `"v1"` {
+			return newTestResponse(req, http.StatusNotModified, nil, ""), nil
+		}
+		return newTestResponse(req, http.StatusOK, http.Header{"Etag": []string{`"v1"`}}, `[{"id":"api"}]`), nil
+	}}
+	cache := &cacheClient{client: client}
+
+	for i := 0; i < 2; i++ {
+		resp, err := cache.Do(newTestRequest(t, http.MethodGet))
+		if err != nil {
+			t.Fatalf("request %d: unexpected error: %v", i, err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("request %d: expected status 200, got %d", i, resp.StatusCode)
+		}
+		if body := readBody(t, resp); body != `[{"id":"api"}]` {
+			t.Errorf("request %d: expected the cached body, got %q", i, body)
+		}
+	}
+
+	if len(client.requests) != 2 {
+		t.Fatalf("expected every read to reach the API without a TTL, got %d requests", len(client.requests))
+	}
+	if etag := client.requests[1].Header.Get("If-None-Match"); etag != `"v1"` {
+		t.Errorf("expected a conditional request, got If-None-Match %q", etag)
+	}
+}
+
+func TestCacheClientSkipsUncacheableResponses(t *testing.T) {
+	tests := map[string]struct {
+		status int
+		header http.Header
+		ttl    time.Duration
+	}{
+		"error": {
+			status: http.StatusNotFound,
+			ttl:    time.Hour,
+		},
+		"no TTL nor validator": {
+			status: http.StatusOK,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &handlerClient{handle: statusSequence(test.header, test.status)}
+			cache := &cacheClient{client: client, ttl: test.ttl}
+
+			for i := 0; i < 2; i++ {
+				if _, err := cache.Do(newTestRequest(t, http.MethodGet)); err != nil {
+					t.Fatalf("request %d: unexpected error: %v", i, err)
+				}
+			}
+			if len(client.requests) != 2 {
+				t.Errorf("expected every read to reach the API, got %d requests", len(client.requests))
+			}
+		})
+	}
+}
+
+func TestCacheClientClearedByWrites(t *testing.T) {
+	client := &handlerClient{handle: statusSequence(nil, http.StatusOK)}
+	cache := &cacheClient{client: client, ttl: time.Hour}
+
+	for _, method := range []string{http.MethodGet, http.MethodPut, http.MethodGet} {
+		if _, err := cache.Do(newTestRequest(t, method)); err != nil {
+			t.Fatalf("%s: unexpected error: %v", method, err)
+		}
+	}
+	if len(client.requests) != 3 {
+		t.Errorf("expected the read after the update to reach the API, got %d requests", len(client.requests))
+	}
+}
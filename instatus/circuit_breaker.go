@@ -0,0 +1,86 @@
+package instatus
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	is "github.com/brunoscota/instatus-client-go"
+)
+
+const (
+	defaultCircuitBreakerThreshold = 5
+	circuitBreakerCooldown         = 30 * time.Second
+)
+
+// circuitOpenError is returned without contacting the API while the circuit
+// breaker is open.
+type circuitOpenError struct {
+	failures  int
+	lastError string
+	retryAt   time.Time
+}
+
+// Error implements the error interface.
+func (e *circuitOpenError) Error() string {
+	return fmt.Sprintf("skipped after %d consecutive failed Instatus API requests (last failure: %s); requests resume at %s",
+		e.failures, e.lastError, e.retryAt.Format(time.RFC3339))
+}
+
+// circuitBreakerClient stops sending requests once the Instatus API failed
+// threshold times in a row, so the remaining operations of an apply fail
+// fast instead of each one exhausting its retries. After a cooldown a single
+// request is let through, and a success closes the circuit again.
+type circuitBreakerClient struct {
+	client    is.HTTPClient
+	threshold int
+
+	mu        sync.Mutex
+	failures  int
+	lastError string
+	openUntil time.Time
+}
+
+// Do sends the request unless the circuit is open.
+func (c *circuitBreakerClient) Do(req *http.Request) (*http.Response, error) {
+	c.mu.Lock()
+	if c.failures >= c.threshold {
+		if time.Now().Before(c.openUntil) {
+			err := &circuitOpenError{failures: c.failures, lastError: c.lastError, retryAt: c.openUntil}
+			c.mu.Unlock()
+			return nil, err
+		}
+		// Half-open: let this request probe the API and hold back the others
+		// until it completes.
+		c.openUntil = time.Now().Add(circuitBreakerCooldown)
+	}
+	c.mu.Unlock()
+
+	resp, err := c.client.Do(req)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// Rate-limited responses don't count: the API is up, and the retry layer
+	// already waits for it.
+	switch {
+	case err != nil && req.Context().Err() == nil:
+		c.recordFailure(err.Error())
+	case err == nil && resp.StatusCode >= http.StatusInternalServerError:
+		c.recordFailure(fmt.Sprintf("%s %s returned %d", req.Method, req.URL.Path, resp.StatusCode))
+	case err == nil && resp.StatusCode != http.StatusTooManyRequests:
+		c.failures = 0
+	}
+
+	return resp, err
+}
+
+// recordFailure counts a failed request and opens the circuit once the
+// threshold is reached. It must be called with mu held.
+func (c *circuitBreakerClient) recordFailure(lastError string) {
+	c.failures++
+	c.lastError = lastError
+	if c.failures >= c.threshold {
+		c.openUntil = time.Now().Add(circuitBreakerCooldown)
+	}
+}
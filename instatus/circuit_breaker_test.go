@@ -0,0 +1,67 @@
+package instatus
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestCircuitBreakerOpensOnServerErrors(t *testing.T) {
+	client := &handlerClient{handle: statusSequence(nil, http.StatusBadGateway)}
+	breaker := &circuitBreakerClient{client: client, threshold: 3}
+
+	for i := 0; i < 3; i++ {
+		if _, err := breaker.Do(newTestRequest(t, http.MethodGet)); err != nil {
+			t.Fatalf("request %d: unexpected error: %v", i, err)
+		}
+	}
+
+	_, err := breaker.Do(newTestRequest(t, http.MethodGet))
+	var circuitErr *circuitOpenError
+	if !errors.As(err, &circuitErr) {
+		t.Fatalf("expected the circuit to be open, got %v", err)
+	}
+	if len(client.requests) != 3 {
+		t.Errorf("expected the request to be skipped, got %d requests", len(client.requests))
+	}
+	if !isTransientError(err) {
+		t.Error("expected an open circuit to be a transient error")
+	}
+}
+
+func TestCircuitBreakerIgnoresRateLimits(t *testing.T) {
+	client := &handlerClient{handle: statusSequence(nil, http.StatusTooManyRequests)}
+	breaker := &circuitBreakerClient{client: client, threshold: 3}
+
+	for i := 0; i < 10; i++ {
+		if _, err := breaker.Do(newTestRequest(t, http.MethodGet)); err != nil {
+			t.Fatalf("request %d: expected rate-limited requests to be sent, got %v", i, err)
+		}
+	}
+	if len(client.requests) != 10 {
+		t.Errorf("expected 10 requests, got %d", len(client.requests))
+	}
+}
+
+func TestCircuitBreakerResetsOnSuccess(t *testing.T) {
+	client := &handlerClient{handle: statusSequence(nil, http.StatusBadGateway, http.StatusBadGateway, http.StatusOK, http.StatusBadGateway, http.StatusBadGateway)}
+	breaker := &circuitBreakerClient{client: client, threshold: 3}
+
+	for i := 0; i < 5; i++ {
+		if _, err := breaker.Do(newTestRequest(t, http.MethodGet)); err != nil {
+			t.Fatalf("request %d: expected the circuit to stay closed, got %v", i, err)
+		}
+	}
+}
+
+// newTestRequest returns a request to the components of the test page.
+func newTestRequest(t *testing.T, method string) *http.Request {
+	t.Helper()
+
+	req, err := http.NewRequest(method, defaultAPIEndpoint+"/"+testPageID+"/components", nil)
+	if err != nil {
+		t.Fatalf("creating request: %v", err)
+	}
+
+	return req
+}
@@ -0,0 +1,218 @@
+package instatus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	is "github.com/brunoscota/instatus-client-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the interface used by the provider.
+var _ instatusClient = &apiClient{}
+
+// instatusClient is the subset of the Instatus API used by data sources and
+// resources. It allows the client implementation to be swapped, e.g. for a
+// mock in unit tests.
+type instatusClient interface {
+	GetUser() (*is.User, error)
+
+	ListPages() ([]page, error)
+
+	ListComponents(pageID string) ([]component, error)
+	CreateComponent(pageID string, component *componentRequest) (*component, error)
+	GetComponent(pageID string, componentID string) (*component, error)
+	UpdateComponent(pageID string, componentID string, component *componentRequest) (*component, error)
+	DeleteComponent(pageID string, componentID string) error
+
+	CreateTemplate(pageID string, template *is.Template) (*is.TemplateFull, error)
+	GetTemplate(pageID, templateID string) (*is.TemplateFull, error)
+	UpdateTemplate(pageID, templateID string, template *is.Template) (*is.TemplateFull, error)
+	DeleteTemplate(pageID, templateID string) error
+}
+
+// newClientFactory returns a function creating Instatus clients whose
+// requests are bound to the given context, so they are cancelled along with
+// the Terraform operation that issued them.
+func newClientFactory(apiKeys []string, httpClient is.HTTPClient) func(ctx context.Context) instatusClient {
+	return func(ctx context.Context) instatusClient {
+		httpClient := &contextClient{
+			ctx:    tflog.MaskAllFieldValuesStrings(ctx, apiKeys...),
+			client: httpClient,
+		}
+
+		client := is.NewClient(apiKeys[0])
+		client.UseHTTPClient(httpClient)
+
+		return &apiClient{
+			Client:     client,
+			apiKey:     apiKeys[0],
+			httpClient: httpClient,
+		}
+	}
+}
+
+// page is a status page as returned by the API.
+type page struct {
+	ID           *string `json:"id"`
+	Subdomain    *string `json:"subdomain,omitempty"`
+	CustomDomain *string `json:"customDomain,omitempty"`
+}
+
+// component is a component as returned by the API, along with the fields the
+// Instatus client does not decode.
+type component struct {
+	is.ComponentFull
+	UniqueEmail *string `json:"uniqueEmail,omitempty"`
+	// WebhookURL and WebhookToken let monitoring tools update the status
+	// of the component.
+	WebhookURL   *string `json:"webhookUrl,omitempty"`
+	WebhookToken *string `json:"webhookToken,omitempty"`
+	Archived     *bool   `json:"archived,omitempty"`
+	StartDate    *string `json:"startDate,omitempty"`
+	// IsParent is set on the components acting as groups.
+	IsParent *bool `json:"isParent,omitempty"`
+
+	Translations *componentTranslations `json:"translations,omitempty"`
+}
+
+// componentRequest is the body of a component create or update request,
+// along with the fields the Instatus client does not encode.
+type componentRequest struct {
+	is.Component
+	Archived  *bool   `json:"archived,omitempty"`
+	StartDate *string `json:"startDate,omitempty"`
+
+	Translations *componentTranslations `json:"translations,omitempty"`
+}
+
+// componentTranslations holds the translated texts of a component, keyed by
+// field and then by locale.
+type componentTranslations struct {
+	Name        map[string]string `json:"name"`
+	Description map[string]string `json:"description"`
+}
+
+// componentsPageSize is the number of components requested per list call.
+const componentsPageSize = 100
+
+// apiClient extends the Instatus client with the endpoints it does not
+// cover yet, sending their requests through the same HTTP client.
+type apiClient struct {
+	*is.Client
+	apiKey     string
+	httpClient is.HTTPClient
+}
+
+// ListPages returns the status pages of the account.
+func (c *apiClient) ListPages() ([]page, error) {
+	var pages []page
+	if err := c.do(http.MethodGet, "/pages", nil, &pages); err != nil {
+		return nil, err
+	}
+
+	return pages, nil
+}
+
+// ListComponents returns all components of a page.
+func (c *apiClient) ListComponents(pageID string) ([]component, error) {
+	var components []component
+	seen := make(map[string]bool)
+
+	for page := 1; ; page++ {
+		var batch []component
+		endpoint := fmt.Sprintf("/%s/components?page=%d&per_page=%d", url.PathEscape(pageID), page, componentsPageSize)
+		if err := c.do(http.MethodGet, endpoint, nil, &batch); err != nil {
+			return nil, err
+		}
+
+		added := 0
+		for _, component := range batch {
+			if component.ID == nil || seen[*component.ID] {
+				continue
+			}
+			seen[*component.ID] = true
+			components = append(components, component)
+			added++
+		}
+
+		// Stop on the last page, or if the API ignores pagination and keeps
+		// returning the same components.
+		if len(batch) < componentsPageSize || added == 0 {
+			return components, nil
+		}
+	}
+}
+
+// CreateComponent creates a component on a page.
+func (c *apiClient) CreateComponent(pageID string, item *componentRequest) (*component, error) {
+	var created component
+	endpoint := fmt.Sprintf("/%s/components", url.PathEscape(pageID))
+	if err := c.do(http.MethodPost, endpoint, item, &created); err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// GetComponent returns a component of a page.
+func (c *apiClient) GetComponent(pageID string, componentID string) (*component, error) {
+	var found component
+	if err := c.do(http.MethodGet, componentEndpoint(pageID, componentID), nil, &found); err != nil {
+		return nil, err
+	}
+
+	return &found, nil
+}
+
+// UpdateComponent updates a component of a page.
+func (c *apiClient) UpdateComponent(pageID string, componentID string, item *componentRequest) (*component, error) {
+	var updated component
+	if err := c.do(http.MethodPut, componentEndpoint(pageID, componentID), item, &updated); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// componentEndpoint returns the API path of a component.
+func componentEndpoint(pageID string, componentID string) string {
+	return fmt.Sprintf("/%s/components/%s", url.PathEscape(pageID), url.PathEscape(componentID))
+}
+
+// do sends a request to an API path, encoding body as JSON if set, and
+// decodes the JSON response into target.
+func (c *apiClient) do(method string, endpoint string, body interface{}, target interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, defaultAPIEndpoint+endpoint, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("%s %s returned %d", method, endpoint, resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(target)
+}
@@ -0,0 +1,114 @@
+package instatus
+
+import (
+	"context"
+	"sync"
+)
+
+// componentBatch serves component reads from a single list call per page,
+// instead of one API request per component during a refresh.
+type componentBatch struct {
+	// newClient creates the client listing the components.
+	newClient func(ctx context.Context) instatusClient
+
+	mu    sync.Mutex
+	pages map[string]*componentBatchPage
+}
+
+// componentBatchPage holds the listed components of one page.
+type componentBatchPage struct {
+	mu sync.Mutex
+	// components is nil until the page is listed successfully.
+	components map[string]*component
+}
+
+// Get returns a component of a page, listing the page's components on first
+// use. It falls back to fetching the component alone with client when
+// listing fails or the component was created after the page was listed.
+func (b *componentBatch) Get(ctx context.Context, client instatusClient, pageID string, componentID string) (*component, error) {
+	components, err := b.list(ctx, pageID)
+	if component, ok := components[componentID]; ok && err == nil {
+		return component, nil
+	}
+
+	return client.GetComponent(pageID, componentID)
+}
+
+// List returns the components of a page, keyed by ID.
+func (b *componentBatch) List(ctx context.Context, pageID string) (map[string]*component, error) {
+	return b.list(ctx, pageID)
+}
+
+// Groups returns the names of the component groups of a page, keyed by ID.
+func (b *componentBatch) Groups(ctx context.Context, pageID string) (map[string]string, error) {
+	components, err := b.list(ctx, pageID)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[string]string)
+	for id, component := range components {
+		if component.IsParent != nil && *component.IsParent {
+			groups[id] = stringValue(component.Name)
+		}
+		if component.Group.Id != nil {
+			groups[*component.Group.Id] = stringValue(component.Group.Name)
+		}
+	}
+
+	return groups, nil
+}
+
+// list returns the listed components of a page, listing them on first use.
+// The list is shared by all the resources of the page, so it is not bound to
+// the cancellation, timeout or max_retries of the resource that happens to
+// request it first, and a failed list is retried by the next caller.
+func (b *componentBatch) list(ctx context.Context, pageID string) (map[string]*component, error) {
+	b.mu.Lock()
+	if b.pages == nil {
+		b.pages = make(map[string]*componentBatchPage)
+	}
+	page, ok := b.pages[pageID]
+	if !ok {
+		page = &componentBatchPage{}
+		b.pages[pageID] = page
+	}
+	b.mu.Unlock()
+
+	page.mu.Lock()
+	defer page.mu.Unlock()
+
+	if page.components != nil {
+		return page.components, nil
+	}
+
+	sharedCtx := context.WithValue(context.WithoutCancel(ctx), maxRetriesKey{}, nil)
+	components, err := b.newClient(sharedCtx).ListComponents(pageID)
+	if err != nil {
+		return nil, err
+	}
+
+	page.components = make(map[string]*component, len(components))
+	for i := range components {
+		page.components[*components[i].ID] = &components[i]
+	}
+
+	return page.components, nil
+}
+
+// stringValue dereferences an optional string returned by the API.
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
+
+// Invalidate discards the listed components of a page after one of them
+// was created, updated or deleted.
+func (b *componentBatch) Invalidate(pageID string) {
+	b.mu.Lock()
+	delete(b.pages, pageID)
+	b.mu.Unlock()
+}
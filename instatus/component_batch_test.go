@@ -0,0 +1,56 @@
+package instatus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestComponentBatchRetriesFailedList(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeClient()
+	client.listErr = &apiError{StatusCode: 503, Method: "GET", Path: "/page/components"}
+	batch := &componentBatch{newClient: func(context.Context) instatusClient { return client }}
+
+	if _, err := batch.List(ctx, testPageID); err == nil {
+		t.Fatal("expected the list error")
+	}
+
+	// Reads fall back to fetching the component alone.
+	component, err := batch.Get(ctx, client, testPageID, "api")
+	if err != nil || stringValue(component.Name) != "API" {
+		t.Fatalf("expected component API, got %v, %v", component, err)
+	}
+
+	client.listErr = nil
+	components, err := batch.List(ctx, testPageID)
+	if err != nil {
+		t.Fatalf("expected the page to be listed again, got %v", err)
+	}
+	if _, ok := components["api"]; !ok {
+		t.Errorf("expected component api to be listed, got %v", components)
+	}
+}
+
+func TestComponentBatchListDetachedFromCaller(t *testing.T) {
+	client := newFakeClient()
+	var listCtx context.Context
+	batch := &componentBatch{newClient: func(ctx context.Context) instatusClient {
+		listCtx = ctx
+		return client
+	}}
+
+	ctx, cancel := context.WithCancel(withMaxRetries(context.Background(), types.Int64Value(0)))
+	cancel()
+
+	if _, err := batch.Groups(ctx, testPageID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if listCtx.Err() != nil {
+		t.Errorf("expected the list not to be cancelled with the caller, got %v", listCtx.Err())
+	}
+	if _, ok := listCtx.Value(maxRetriesKey{}).(int); ok {
+		t.Error("expected the list not to use the max_retries of the caller")
+	}
+}
@@ -0,0 +1,130 @@
+package instatus
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ list.ListResource              = &componentListResource{}
+	_ list.ListResourceWithConfigure = &componentListResource{}
+)
+
+// NewComponentListResource is a helper function to simplify the provider implementation.
+func NewComponentListResource() list.ListResource {
+	return &componentListResource{}
+}
+
+// componentListResource lists the components of a page, so terraform query
+// can generate the configuration of the components not managed yet.
+type componentListResource struct {
+	componentResource
+}
+
+// componentListModel maps the list resource schema data.
+type componentListModel struct {
+	PageID types.String `tfsdk:"page_id"`
+}
+
+// Configure adds the provider configured client to the list resource.
+func (r *componentListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.componentResource.Configure(ctx, req, resp)
+}
+
+// ListResourceConfigSchema defines the schema for list blocks.
+func (r *componentListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		Description: "Lists the components of a page, groups excluded, ordered by name.",
+		Attributes: map[string]listschema.Attribute{
+			"page_id": listschema.StringAttribute{
+				Description: "String Identifier of the page of the components. Defaults to the provider default_page_id.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+// List streams the components of the page.
+func (r *componentListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config componentListModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	pageID := config.PageID.ValueString()
+	if config.PageID.IsNull() {
+		pageID = r.defaultPageID
+	}
+	if pageID == "" {
+		diags.AddAttributeError(
+			path.Root("page_id"),
+			"Missing Page ID",
+			"The page_id attribute must be set on the list block, or default_page_id must be set on the provider.",
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	components, err := r.provider.Client(ctx).ListComponents(pageID)
+	diags.Append(r.provider.quota.Diagnostics()...)
+	if err != nil {
+		addAPIError(
+			&diags,
+			"Unable to List Instatus Components",
+			"Could not read the components of Instatus page ID "+pageID,
+			err,
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	listed := make([]component, 0, len(components))
+	for _, component := range components {
+		if component.IsParent != nil && *component.IsParent {
+			continue
+		}
+		listed = append(listed, component)
+	}
+	sort.SliceStable(listed, func(i, j int) bool {
+		return stringValue(listed[i].Name) < stringValue(listed[j].Name)
+	})
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for i, component := range listed {
+			if req.Limit > 0 && int64(i) >= req.Limit {
+				return
+			}
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = stringValue(component.Name)
+			result.Diagnostics.Append(diags...)
+			diags = nil
+
+			setComponentIdentity(ctx, result.Identity, types.StringValue(pageID), types.StringPointerValue(component.ID), &result.Diagnostics)
+			if req.IncludeResource {
+				r.setListedResource(ctx, pageID, component, &result)
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+// setListedResource sets the attributes of a listed component, as Read
+// refreshes an imported one.
+func (r *componentListResource) setListedResource(ctx context.Context, pageID string, component component, result *list.ListResult) {
+	for name, value := range r.componentAttributes(ctx, pageID, component) {
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root(name), value)...)
+	}
+}
@@ -2,24 +2,51 @@ package instatus
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 
 	is "github.com/brunoscota/instatus-client-go"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &componentResource{}
-	_ resource.ResourceWithConfigure   = &componentResource{}
-	_ resource.ResourceWithImportState = &componentResource{}
+	_ resource.Resource                 = &componentResource{}
+	_ resource.ResourceWithConfigure    = &componentResource{}
+	_ resource.ResourceWithImportState  = &componentResource{}
+	_ resource.ResourceWithModifyPlan   = &componentResource{}
+	_ resource.ResourceWithUpgradeState = &componentResource{}
+
+	_ resource.ResourceWithConfigValidators = &componentResource{}
+	_ resource.ResourceWithValidateConfig   = &componentResource{}
+	_ resource.ResourceWithIdentity         = &componentResource{}
+	_ resource.ResourceWithMoveState        = &componentResource{}
 )
 
 // Configure adds the provider configured client to the resource.
@@ -28,7 +55,8 @@ func (r *componentResource) Configure(_ context.Context, req resource.ConfigureR
 		return
 	}
 
-	r.client = req.ProviderData.(*is.Client)
+	r.provider = req.ProviderData.(*instatusProviderData)
+	r.defaultPageID = r.provider.defaultPageID
 }
 
 // NewComponentResource is a helper function to simplify the provider implementation.
@@ -38,19 +66,71 @@ func NewComponentResource() resource.Resource {
 
 // componentResource is the resource implementation.
 type componentResource struct {
-	client *is.Client
+	provider      *instatusProviderData
+	defaultPageID string
 }
 
 // componentResourceModel maps the resource schema data.
 type componentResourceModel struct {
-	ID          types.String `tfsdk:"id"`
+	ID                   types.String                         `tfsdk:"id"`
+	Name                 types.String                         `tfsdk:"name"`
+	PageID               types.String                         `tfsdk:"page_id"`
+	Description          types.String                         `tfsdk:"description"`
+	ShowUptime           types.Bool                           `tfsdk:"show_uptime"`
+	Grouped              types.Bool                           `tfsdk:"grouped"`
+	GroupName            types.String                         `tfsdk:"group_name"`
+	GroupId              types.String                         `tfsdk:"group_id"`
+	Group                types.Object                         `tfsdk:"group"`
+	PreventDuplicateName types.Bool                           `tfsdk:"prevent_duplicate_name"`
+	AutoCreateGroup      types.Bool                           `tfsdk:"auto_create_group"`
+	Archived             types.Bool                           `tfsdk:"archived"`
+	StartDate            types.String                         `tfsdk:"start_date"`
+	Translations         map[string]componentTranslationModel `tfsdk:"translations"`
+	UniqueEmail          types.String                         `tfsdk:"unique_email"`
+	WebhookURL           types.String                         `tfsdk:"webhook_url"`
+	WebhookToken         types.String                         `tfsdk:"webhook_token"`
+	URL                  types.String                         `tfsdk:"url"`
+	DeletionPolicy       types.String                         `tfsdk:"deletion_policy"`
+	MaxRetries           types.Int64                          `tfsdk:"max_retries"`
+	Timeouts             timeouts.Value                       `tfsdk:"timeouts"`
+}
+
+// Deletion policies of a component.
+const (
+	deletionPolicyDelete  = "delete"
+	deletionPolicyArchive = "archive"
+	deletionPolicyAbandon = "abandon"
+)
+
+// Maximum lengths of the names and descriptions of components accepted by
+// the API.
+const (
+	componentNameMaxLength        = 255
+	componentDescriptionMaxLength = 1000
+)
+
+// componentIdentityModel maps the identity schema data.
+type componentIdentityModel struct {
+	PageID types.String `tfsdk:"page_id"`
+	ID     types.String `tfsdk:"id"`
+}
+
+// componentGroupModel maps the group of a component.
+type componentGroupModel struct {
+	ID   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+}
+
+// componentGroupAttrTypes are the attribute types of the group of a component.
+var componentGroupAttrTypes = map[string]attr.Type{
+	"id":   types.StringType,
+	"name": types.StringType,
+}
+
+// componentTranslationModel maps the translation of a component to a locale.
+type componentTranslationModel struct {
 	Name        types.String `tfsdk:"name"`
-	PageID      types.String `tfsdk:"page_id"`
 	Description types.String `tfsdk:"description"`
-	ShowUptime  types.Bool   `tfsdk:"show_uptime"`
-	Grouped     types.Bool   `tfsdk:"grouped"`
-	GroupName   types.String `tfsdk:"group_name"`
-	GroupId     types.String `tfsdk:"group_id"`
 }
 
 // Metadata returns the resource type name.
@@ -59,9 +139,10 @@ func (r *componentResource) Metadata(_ context.Context, req resource.MetadataReq
 }
 
 // Schema defines the schema for the resource.
-func (r *componentResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *componentResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages a component.",
+		Version:     5,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "String Identifier of the component.",
@@ -71,36 +152,501 @@ func (r *componentResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				},
 			},
 			"page_id": schema.StringAttribute{
-				Description: "String Identifier of the page of the component.",
-				Required:    true,
+				Description: "String Identifier of the page of the component. Defaults to the provider default_page_id. Changing it forces a new component to be created.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"name": schema.StringAttribute{
-				Description: "Name of the component.",
+				Description: fmt.Sprintf("Name of the component, up to %d characters.", componentNameMaxLength),
 				Required:    true,
+				Validators:  componentNameValidators(),
 			},
 			"description": schema.StringAttribute{
-				Description: "Description of the component.",
+				Description: fmt.Sprintf("Description of the component, up to %d characters.", componentDescriptionMaxLength),
 				Optional:    true,
+				Validators:  componentDescriptionValidators(),
 			},
 			"show_uptime": schema.BoolAttribute{
-				Description: "Whether show uptime is enabled in the component.",
+				Description: "Whether show uptime is enabled in the component. Defaults to true.",
 				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
 			},
 			"grouped": schema.BoolAttribute{
-				Description: "Whether the component is in a group (Require group set to desired name when true).",
+				Description: "Whether the component is in a group (Require group_name or group_id when true). Derived from group_name, group_id and group when omitted.",
 				Optional:    true,
 				Computed:    true,
-				Default:     booldefault.StaticBool(false),
+				DeprecationMessage: "The grouped attribute is derived from group_name, group_id and group, and will be removed in a future version. " +
+					"Remove it from the configuration.",
 			},
 			"group_name": schema.StringAttribute{
-				Description: "Name of the group for the component (Require grouped set to true).",
+				Description: "Name of the group for the component.",
+				Optional:    true,
+				Validators:  componentNameValidators(),
+			},
+			"auto_create_group": schema.BoolAttribute{
+				Description: "Whether to create the group named by group_name when it does not exist yet. When false, group_name must name an existing group of the page.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"group": schema.SingleNestedAttribute{
+				Description: "Group of the component, as an alternative to group_name and group_id.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"id": schema.StringAttribute{
+						Description: "String Identifier of the group.",
+						Optional:    true,
+						Computed:    true,
+					},
+					"name": schema.StringAttribute{
+						Description: "Name of the group.",
+						Optional:    true,
+						Computed:    true,
+						Validators: append(componentNameValidators(),
+							stringvalidator.AtLeastOneOf(path.MatchRelative().AtParent().AtName("id")),
+						),
+					},
+				},
+				Validators: []validator.Object{
+					objectvalidator.ConflictsWith(
+						path.MatchRoot("grouped"),
+						path.MatchRoot("group_name"),
+						path.MatchRoot("group_id"),
+					),
+				},
+			},
+			"archived": schema.BoolAttribute{
+				Description: "Whether the component is archived. Archived components are hidden from the status page but keep their uptime history.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"start_date": schema.StringAttribute{
+				Description: "Date the uptime history of the component starts at, in the YYYY-MM-DD format. Defaults to the creation date of the component.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`), "must be a date in the YYYY-MM-DD format"),
+				},
+			},
+			"translations": schema.MapNestedAttribute{
+				Description: "Translations of the component, keyed by locale (e.g. fr).",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "Translated name of the component.",
+							Optional:    true,
+							Validators:  componentNameValidators(),
+						},
+						"description": schema.StringAttribute{
+							Description: "Translated description of the component.",
+							Optional:    true,
+							Validators:  componentDescriptionValidators(),
+						},
+					},
+				},
+			},
+			"unique_email": schema.StringAttribute{
+				Description: "Email address that monitoring tools can send alerts to in order to update the status of the component.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"webhook_url": schema.StringAttribute{
+				Description: "URL of the webhook that monitoring tools can call in order to update the status of the component.",
+				Computed:    true,
+				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"webhook_token": schema.StringAttribute{
+				Description: "Token authenticating the calls to the webhook of the component.",
+				Computed:    true,
+				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"url": schema.StringAttribute{
+				Description: "URL of the component on the public status page.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"prevent_duplicate_name": schema.BoolAttribute{
+				Description: "Whether to fail the plan when another component of the page already has the same name.",
 				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"deletion_policy": schema.StringAttribute{
+				Description: fmt.Sprintf("What destroying the resource does to the component. One of: %s (the default) removes it, %s hides it from the status page but keeps its uptime history, %s leaves it untouched.", deletionPolicyDelete, deletionPolicyArchive, deletionPolicyAbandon),
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(deletionPolicyDelete),
+				Validators: []validator.String{
+					stringvalidator.OneOf(deletionPolicyDelete, deletionPolicyArchive, deletionPolicyAbandon),
+				},
+			},
+			"max_retries": schema.Int64Attribute{
+				Description: "Maximum number of times the API requests of this component are retried, overriding the provider max_retries. Set to 0 to disable retries.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
 			},
 			"group_id": schema.StringAttribute{
-				Description: "Name of the group for the component (Require grouped set to true).",
+				Description: "String Identifier of the group for the component.",
 				Optional:    true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// ConfigValidators returns the validations spanning several attributes.
+func (r *componentResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.Conflicting(
+			path.MatchRoot("group_name"),
+			path.MatchRoot("group_id"),
+		),
+	}
+}
+
+// ValidateConfig checks that the group settings of the component agree.
+func (r *componentResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var grouped types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("grouped"), &grouped)...)
+	if resp.Diagnostics.HasError() || grouped.IsNull() || grouped.IsUnknown() {
+		return
+	}
+
+	var groupName, groupID types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("group_name"), &groupName)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("group_id"), &groupID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !grouped.ValueBool() {
+		for attribute, value := range map[string]types.String{"group_name": groupName, "group_id": groupID} {
+			if !value.IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(attribute),
+					"Invalid Component Group",
+					"The "+attribute+" attribute can't be set when grouped is false. Set grouped to true or remove "+attribute+".",
+				)
+			}
+		}
+		return
+	}
+
+	if groupName.IsNull() && groupID.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("grouped"),
+			"Missing Component Group",
+			"A component with grouped set to true must set group_name to the group it belongs to, or group_id to an existing group.",
+		)
+	}
+}
+
+// IdentitySchema defines the attributes identifying a component.
+func (r *componentResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"page_id": identityschema.StringAttribute{
+				Description:       "String Identifier of the page of the component.",
+				RequiredForImport: true,
+			},
+			"id": identityschema.StringAttribute{
+				Description:       "String Identifier of the component.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+// UpgradeState upgrades states written by earlier versions of the resource.
+func (r *componentResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	// Each version only added attributes or defaults, so all prior versions
+	// upgrade the same way:
+	//   - version 1 added the group attribute, left null for existing
+	//     components which keep using grouped, group_name and group_id.
+	//   - version 2 made show_uptime default to true, as the API does.
+	//   - version 3 added deletion_policy, which defaults to delete.
+	//   - version 4 derived grouped from the group attributes, existing
+	//     values being kept as they are.
+	//   - version 5 set auto_create_group and prevent_duplicate_name, which
+	//     default to false.
+	upgrader := resource.StateUpgrader{
+		StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+			upgradeStateToSchema(ctx, schemaResp.Schema, componentStateDefaults, req, resp)
+		},
+	}
+
+	return map[int64]resource.StateUpgrader{
+		0: upgrader,
+		1: upgrader,
+		2: upgrader,
+		3: upgrader,
+		4: upgrader,
+	}
+}
+
+// componentStateDefaults are the values of the attributes missing from the
+// states written by earlier versions of the resource or by other providers.
+var componentStateDefaults = map[string]tftypes.Value{
+	"show_uptime":            tftypes.NewValue(tftypes.Bool, true),
+	"deletion_policy":        tftypes.NewValue(tftypes.String, deletionPolicyDelete),
+	"auto_create_group":      tftypes.NewValue(tftypes.Bool, false),
+	"prevent_duplicate_name": tftypes.NewValue(tftypes.Bool, false),
+}
+
+// MoveState moves the state of components managed by other providers, with
+// moved blocks:
+//   - instatus_component resources of older or forked Instatus providers,
+//     whose state is kept as is.
+//   - statuspage_component resources of the Atlassian Statuspage provider,
+//     which adopt the component of the same name on the default page, as
+//     Statuspage components don't exist in Instatus.
+func (r *componentResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		{
+			StateMover: r.moveInstatusComponent,
+		},
+		{
+			StateMover: r.moveStatuspageComponent,
+		},
+	}
+}
+
+// moveInstatusComponent moves an instatus_component of another provider.
+func (r *componentResource) moveInstatusComponent(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+	if req.SourceTypeName != "instatus_component" {
+		return
+	}
+
+	targetState, err := rawStateToSchema(resp.TargetState.Schema.Type().TerraformType(ctx), componentStateDefaults, req.SourceRawState)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Move Resource State",
+			"The state of the "+req.SourceProviderAddress+" component could not be read: "+err.Error(),
+		)
+		return
+	}
+	resp.TargetState.Raw = targetState
+
+	var pageID, id types.String
+	resp.Diagnostics.Append(resp.TargetState.GetAttribute(ctx, path.Root("page_id"), &pageID)...)
+	resp.Diagnostics.Append(resp.TargetState.GetAttribute(ctx, path.Root("id"), &id)...)
+	setComponentIdentity(ctx, resp.TargetIdentity, pageID, id, &resp.Diagnostics)
+}
+
+// moveStatuspageComponent moves a statuspage_component of the Atlassian
+// Statuspage provider to the component of the same name on the default page.
+func (r *componentResource) moveStatuspageComponent(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+	if req.SourceTypeName != "statuspage_component" {
+		return
+	}
+
+	var source struct {
+		Name string `json:"name"`
+	}
+	if req.SourceRawState == nil || json.Unmarshal(req.SourceRawState.JSON, &source) != nil || source.Name == "" {
+		resp.Diagnostics.AddError(
+			"Unable to Move Resource State",
+			"The name of the Statuspage component could not be read from its state.",
+		)
+		return
+	}
+
+	if r.provider == nil || r.defaultPageID == "" {
+		resp.Diagnostics.AddError(
+			"Unable to Move Resource State",
+			"Statuspage components are moved to the Instatus component of the same name on the provider default_page_id, which must be set.",
+		)
+		return
+	}
+
+	components, err := r.provider.components.List(ctx, r.defaultPageID)
+	resp.Diagnostics.Append(r.provider.quota.Diagnostics()...)
+	if err != nil {
+		addAPIError(
+			&resp.Diagnostics,
+			"Unable to Move Resource State",
+			"Could not read the components of Instatus page ID "+r.defaultPageID,
+			err,
+		)
+		return
+	}
+
+	for _, component := range components {
+		if component.IsParent != nil && *component.IsParent {
+			continue
+		}
+		if stringValue(component.Name) != strings.TrimSpace(source.Name) {
+			continue
+		}
+
+		for name, value := range r.componentAttributes(ctx, r.defaultPageID, *component) {
+			resp.Diagnostics.Append(resp.TargetState.SetAttribute(ctx, path.Root(name), value)...)
+		}
+		setComponentIdentity(ctx, resp.TargetIdentity, types.StringValue(r.defaultPageID), types.StringPointerValue(component.ID), &resp.Diagnostics)
+		return
+	}
+
+	resp.Diagnostics.AddError(
+		"Unable to Move Resource State",
+		"No component named "+strconv.Quote(source.Name)+" was found on Instatus page ID "+r.defaultPageID+". "+
+			"Create the component in Instatus before moving the Statuspage component to it.",
+	)
+}
+
+// ModifyPlan fills in the page_id from the provider configuration when
+// omitted and checks the planned component against the page.
+func (r *componentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planDefaultPageID(ctx, r.defaultPageID, req, resp)
+	if resp.Diagnostics.HasError() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	planGrouped(ctx, req, resp)
+	if resp.Diagnostics.HasError() || r.provider == nil {
+		return
+	}
+
+	var pageID types.String
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("page_id"), &pageID)...)
+	if resp.Diagnostics.HasError() || pageID.IsUnknown() {
+		return
+	}
+
+	r.checkGroupExists(ctx, pageID.ValueString(), resp)
+	r.checkDuplicateName(ctx, pageID.ValueString(), req, resp)
+}
+
+// planGrouped derives the planned grouped attribute from the group
+// attributes when it is omitted from the configuration.
+func planGrouped(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var grouped types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("grouped"), &grouped)...)
+	if resp.Diagnostics.HasError() || !grouped.IsNull() {
+		return
+	}
+
+	var groupName, groupID types.String
+	var group types.Object
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("group_name"), &groupName)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("group_id"), &groupID)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("group"), &group)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if groupName.IsUnknown() || groupID.IsUnknown() || group.IsUnknown() {
+		grouped = types.BoolUnknown()
+	} else {
+		grouped = types.BoolValue(!groupName.IsNull() || !groupID.IsNull() || !group.IsNull())
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("grouped"), grouped)...)
+}
+
+// checkGroupExists checks that the group referenced by group_id exists
+// before anything is created.
+func (r *componentResource) checkGroupExists(ctx context.Context, pageID string, resp *resource.ModifyPlanResponse) {
+	var groupID types.String
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("group_id"), &groupID)...)
+	if resp.Diagnostics.HasError() || groupID.IsNull() || groupID.IsUnknown() {
+		return
+	}
+
+	groups, err := r.provider.components.Groups(ctx, pageID)
+	if err != nil {
+		tflog.Debug(ctx, "Unable to list the component groups of the Instatus page, skipping the group check", map[string]interface{}{
+			"page_id": pageID,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	if _, ok := groups[groupID.ValueString()]; !ok {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("group_id"),
+			"Component Group Not Found",
+			"No component group with ID "+groupID.ValueString()+" exists on page "+pageID+". "+
+				"Check that group_id refers to a group of the page of the component.",
+		)
+	}
+}
+
+// checkDuplicateName fails the plan when prevent_duplicate_name is set and
+// another component of the page already has the planned name.
+func (r *componentResource) checkDuplicateName(ctx context.Context, pageID string, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var preventDuplicate types.Bool
+	var name, id types.String
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("prevent_duplicate_name"), &preventDuplicate)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("name"), &name)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("id"), &id)...)
+	if resp.Diagnostics.HasError() || !preventDuplicate.ValueBool() || name.IsUnknown() {
+		return
+	}
+
+	// Only check new components and renames, an existing duplicate is
+	// reported once rather than on every plan.
+	if !req.State.Raw.IsNull() {
+		var priorName types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("name"), &priorName)...)
+		if resp.Diagnostics.HasError() || priorName.Equal(name) {
+			return
+		}
+	}
+
+	components, err := r.provider.components.List(ctx, pageID)
+	if err != nil {
+		tflog.Debug(ctx, "Unable to list the components of the Instatus page, skipping the duplicate name check", map[string]interface{}{
+			"page_id": pageID,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	// Groups are listed as components too, and may share the name of one of
+	// their components.
+	trimmed := strings.TrimSpace(name.ValueString())
+	for componentID, component := range components {
+		if component.IsParent != nil && *component.IsParent {
+			continue
+		}
+		if componentID != id.ValueString() && component.Name != nil && *component.Name == trimmed {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name"),
+				"Duplicate Component Name",
+				"Component "+componentID+" of page "+pageID+" is already named "+trimmed+". "+
+					"Import it instead of creating a new component, or choose another name.",
+			)
+			return
+		}
 	}
 }
 
@@ -114,30 +660,82 @@ func (r *componentResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
-	var item is.Component = is.Component{
-		Name:        plan.Name.ValueStringPointer(),
-		Description: plan.Description.ValueStringPointer(),
-		ShowUptime:  plan.ShowUptime.ValueBoolPointer(),
-		Grouped:     plan.Grouped.ValueBoolPointer(),
-		Group:       plan.GroupId.ValueStringPointer(),
-		GroupId:     plan.GroupId.ValueStringPointer(),
+	ctx = withMaxRetries(ctx, plan.MaxRetries)
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	var item componentRequest = componentRequest{
+		Component: is.Component{
+			Name:        trimmedStringPointer(plan.Name),
+			Description: plan.Description.ValueStringPointer(),
+			ShowUptime:  plan.ShowUptime.ValueBoolPointer(),
+			Grouped:     plan.Grouped.ValueBoolPointer(),
+			Group:       trimmedStringPointer(plan.GroupName),
+			GroupId:     plan.GroupId.ValueStringPointer(),
+		},
+		Archived:     plan.Archived.ValueBoolPointer(),
+		StartDate:    knownStringPointer(plan.StartDate),
+		Translations: newComponentTranslations(plan.Translations),
+	}
+	resp.Diagnostics.Append(applyComponentGroup(ctx, plan.Group, &item)...)
+	resp.Diagnostics.Append(resolveComponentGroup(ctx, r.provider, plan.PageID.ValueString(), plan.AutoCreateGroup.ValueBool(), path.Root("group_name"), &item)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	// Create new component
-	component, err := r.client.CreateComponent(plan.PageID.ValueString(), &item)
+	component, err := r.provider.Client(ctx).CreateComponent(plan.PageID.ValueString(), &item)
+	r.provider.components.Invalidate(plan.PageID.ValueString())
+	resp.Diagnostics.Append(r.provider.quota.Diagnostics()...)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addAPIError(
+			&resp.Diagnostics,
 			"Error creating component",
-			"Could not create component, unexpected error: "+err.Error(),
+			"Could not create component",
+			err,
 		)
 		return
 	}
 
+	// Read the component back, as the API may briefly report a new
+	// component as missing.
+	if component.ID != nil {
+		err = retryNotFound(ctx, func() error {
+			created, err := r.provider.Client(ctx).GetComponent(plan.PageID.ValueString(), *component.ID)
+			if err == nil {
+				component = created
+			}
+			return err
+		})
+		if err != nil {
+			resp.Diagnostics.AddWarning(
+				"Instatus Component Not Readable After Creation",
+				"Component ID "+*component.ID+" was created but could not be read back, its state was populated from the creation response: "+err.Error(),
+			)
+		}
+	}
+
 	// Map response body to schema and populate Computed attribute values
 	plan.ID = types.StringPointerValue(component.ID)
 	plan.Description = types.StringPointerValue(component.Description)
-	plan.GroupName = types.StringPointerValue(component.Group.Name)
-	plan.GroupId = types.StringPointerValue(component.Group.Id)
+	// group_name, group_id and translations keep their planned values, Read
+	// reports any drift from them.
+	if !plan.Group.IsNull() {
+		plan.Group = refreshComponentGroup(plan.Group, component.Group)
+	}
+	plan.UniqueEmail = types.StringPointerValue(component.UniqueEmail)
+	plan.WebhookURL = types.StringPointerValue(component.WebhookURL)
+	plan.WebhookToken = types.StringPointerValue(component.WebhookToken)
+	plan.Archived = types.BoolValue(component.Archived != nil && *component.Archived)
+	plan.StartDate = componentStartDate(component.StartDate)
+	plan.URL = r.componentURL(ctx, plan.PageID.ValueString(), plan.ID.ValueString(), plan.URL)
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
@@ -145,6 +743,8 @@ func (r *componentResource) Create(ctx context.Context, req resource.CreateReque
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	setComponentIdentity(ctx, resp.Identity, plan.PageID, plan.ID, &resp.Diagnostics)
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -157,22 +757,66 @@ func (r *componentResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	ctx = withMaxRetries(ctx, state.MaxRetries)
+
 	// Get refreshed component value from Instatus
-	component, err := r.client.GetComponent(state.PageID.ValueString(), state.ID.ValueString())
+	component, err := r.provider.components.Get(ctx, r.provider.Client(ctx), state.PageID.ValueString(), state.ID.ValueString())
+	resp.Diagnostics.Append(r.provider.quota.Diagnostics()...)
+	if isNotFound(err) {
+		// The component was deleted outside of Terraform, let the next plan
+		// recreate it.
+		tflog.Warn(ctx, "Instatus component not found, removing it from state", map[string]interface{}{
+			"id": state.ID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil && r.provider.tolerateReadErrors && isTransientError(err) {
+		resp.Diagnostics.AddWarning(
+			"Instatus Component Not Refreshed",
+			"Could not reach the Instatus API to refresh component ID "+state.ID.ValueString()+", the previous state is kept: "+err.Error(),
+		)
+		// States written before identities were supported have none yet.
+		setComponentIdentity(ctx, resp.Identity, state.PageID, state.ID, &resp.Diagnostics)
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addAPIError(
+			&resp.Diagnostics,
 			"Error Reading Instatus Component",
-			"Could not read Instatus component ID "+state.ID.ValueString()+": "+err.Error(),
+			"Could not read Instatus component ID "+state.ID.ValueString(),
+			err,
 		)
 		return
 	}
 	// Overwrite items with refreshed state
-	state.Name = types.StringPointerValue(component.Name)
+	state.Name = keepUntrimmed(state.Name, component.Name)
 	state.Description = types.StringPointerValue(component.Description)
-	state.ShowUptime = types.BoolPointerValue(component.ShowUptime)
-	state.Grouped = types.BoolValue(component.Group.Name != nil)
-	state.GroupName = types.StringPointerValue(component.Group.Name)
-	state.GroupId = types.StringPointerValue(component.Group.Id)
+	if component.ShowUptime != nil {
+		state.ShowUptime = types.BoolValue(*component.ShowUptime)
+	}
+	imported := state.Grouped.IsNull()
+	state.Grouped = types.BoolValue(component.Group.Id != nil || component.Group.Name != nil)
+	if state.Group.IsNull() {
+		// Only refresh the group attributes in use, so a component referring
+		// to its group by name doesn't get a group_id it was not given. An
+		// imported component has neither and refers to its group by name.
+		if !state.GroupName.IsNull() || imported {
+			state.GroupName = keepUntrimmed(state.GroupName, component.Group.Name)
+		}
+		if !state.GroupId.IsNull() {
+			state.GroupId = types.StringPointerValue(component.Group.Id)
+		}
+	} else {
+		state.Group = refreshComponentGroup(state.Group, component.Group)
+	}
+	state.UniqueEmail = types.StringPointerValue(component.UniqueEmail)
+	state.WebhookURL = types.StringPointerValue(component.WebhookURL)
+	state.WebhookToken = types.StringPointerValue(component.WebhookToken)
+	state.Archived = types.BoolValue(component.Archived != nil && *component.Archived)
+	state.StartDate = componentStartDate(component.StartDate)
+	state.Translations = refreshComponentTranslations(state.Translations, component.Translations)
+	state.URL = r.componentURL(ctx, state.PageID.ValueString(), state.ID.ValueString(), state.URL)
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
@@ -180,6 +824,8 @@ func (r *componentResource) Read(ctx context.Context, req resource.ReadRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	setComponentIdentity(ctx, resp.Identity, state.PageID, state.ID, &resp.Diagnostics)
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
@@ -192,31 +838,65 @@ func (r *componentResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
+	ctx = withMaxRetries(ctx, plan.MaxRetries)
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	// Generate API request body from plan
-	var item is.Component = is.Component{
-		Name:        plan.Name.ValueStringPointer(),
-		Description: plan.Description.ValueStringPointer(),
-		ShowUptime:  plan.ShowUptime.ValueBoolPointer(),
-		Grouped:     plan.Grouped.ValueBoolPointer(),
-		Group:       plan.GroupName.ValueStringPointer(),
-		GroupId:     plan.GroupId.ValueStringPointer(),
+	var item componentRequest = componentRequest{
+		Component: is.Component{
+			Name:        trimmedStringPointer(plan.Name),
+			Description: plan.Description.ValueStringPointer(),
+			ShowUptime:  plan.ShowUptime.ValueBoolPointer(),
+			Grouped:     plan.Grouped.ValueBoolPointer(),
+			Group:       trimmedStringPointer(plan.GroupName),
+			GroupId:     plan.GroupId.ValueStringPointer(),
+		},
+		Archived:     plan.Archived.ValueBoolPointer(),
+		StartDate:    knownStringPointer(plan.StartDate),
+		Translations: newComponentTranslations(plan.Translations),
+	}
+	resp.Diagnostics.Append(applyComponentGroup(ctx, plan.Group, &item)...)
+	resp.Diagnostics.Append(resolveComponentGroup(ctx, r.provider, plan.PageID.ValueString(), plan.AutoCreateGroup.ValueBool(), path.Root("group_name"), &item)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	// Update existing component
-	component, err := r.client.UpdateComponent(plan.PageID.ValueString(), plan.ID.ValueString(), &item)
+	component, err := r.provider.Client(ctx).UpdateComponent(plan.PageID.ValueString(), plan.ID.ValueString(), &item)
+	r.provider.components.Invalidate(plan.PageID.ValueString())
+	resp.Diagnostics.Append(r.provider.quota.Diagnostics()...)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addAPIError(
+			&resp.Diagnostics,
 			"Error Updating Instatus Component",
-			"Could not update component, unexpected error: "+err.Error(),
+			"Could not update component",
+			err,
 		)
 		return
 	}
 
 	// Map response body to schema and populate Computed attribute values
 	plan.ID = types.StringPointerValue(component.ID)
-	plan.GroupName = types.StringPointerValue(component.Group.Name)
 	plan.Description = types.StringPointerValue(component.Description)
-	plan.GroupId = types.StringPointerValue(component.Group.Id)
+	// group_name, group_id and translations keep their planned values, Read
+	// reports any drift from them.
+	if !plan.Group.IsNull() {
+		plan.Group = refreshComponentGroup(plan.Group, component.Group)
+	}
+	plan.UniqueEmail = types.StringPointerValue(component.UniqueEmail)
+	plan.WebhookURL = types.StringPointerValue(component.WebhookURL)
+	plan.WebhookToken = types.StringPointerValue(component.WebhookToken)
+	plan.Archived = types.BoolValue(component.Archived != nil && *component.Archived)
+	plan.StartDate = componentStartDate(component.StartDate)
+	plan.URL = r.componentURL(ctx, plan.PageID.ValueString(), plan.ID.ValueString(), plan.URL)
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
@@ -224,6 +904,8 @@ func (r *componentResource) Update(ctx context.Context, req resource.UpdateReque
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	setComponentIdentity(ctx, resp.Identity, plan.PageID, plan.ID, &resp.Diagnostics)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -236,20 +918,77 @@ func (r *componentResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
-	// Delete existing component
-	err := r.client.DeleteComponent(state.PageID.ValueString(), state.ID.ValueString())
+	ctx = withMaxRetries(ctx, state.MaxRetries)
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	var err error
+	switch state.DeletionPolicy.ValueString() {
+	case deletionPolicyAbandon:
+		tflog.Info(ctx, "Leaving the Instatus component in place as its deletion_policy is abandon", map[string]interface{}{
+			"id": state.ID.ValueString(),
+		})
+		return
+	case deletionPolicyArchive:
+		// Archive the component to keep its uptime history
+		archived := true
+		_, err = r.provider.Client(ctx).UpdateComponent(state.PageID.ValueString(), state.ID.ValueString(), &componentRequest{
+			Archived: &archived,
+		})
+	default:
+		// Delete existing component
+		err = r.provider.Client(ctx).DeleteComponent(state.PageID.ValueString(), state.ID.ValueString())
+	}
+	r.provider.components.Invalidate(state.PageID.ValueString())
+	resp.Diagnostics.Append(r.provider.quota.Diagnostics()...)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addAPIError(
+			&resp.Diagnostics,
 			"Error Deleting Instatus Component",
-			"Could not delete component, unexpected error: "+err.Error(),
+			"Could not delete component",
+			err,
 		)
 		return
 	}
 }
 
 func (r *componentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import blocks may identify the component by its identity rather than
+	// an identifier.
+	if req.ID == "" && req.Identity != nil {
+		var identity componentIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("page_id"), identity.PageID)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), identity.ID)...)
+		resp.Diagnostics.Append(setImportedComponentDefaults(ctx, &resp.State)...)
+		return
+	}
+
 	idParts := strings.Split(req.ID, "/") // Splitting by '/' for "PageId/id"
 
+	// A resource only imports a single component, point to the ways of
+	// importing them all.
+	if len(idParts) == 2 && idParts[1] == "*" {
+		resp.Diagnostics.AddError(
+			"Wildcard Import Not Supported",
+			"An instatus_component resource imports a single component. To import all the components of page "+idParts[0]+", "+
+				"import them as one instatus_components resource with the page ID as identifier, or use import blocks "+
+				"iterating over the import_id of the components listed by the instatus_components data source.",
+		)
+		return
+	}
+
 	// Check if the split results exactly in two parts and neither part is empty
 	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
 		resp.Diagnostics.AddError(
@@ -261,4 +1000,301 @@ func (r *componentResource) ImportState(ctx context.Context, req resource.Import
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("page_id"), idParts[0])...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), idParts[1])...)
+	resp.Diagnostics.Append(setImportedComponentDefaults(ctx, &resp.State)...)
+	setComponentIdentity(ctx, resp.Identity, types.StringValue(idParts[0]), types.StringValue(idParts[1]), &resp.Diagnostics)
+}
+
+// setImportedComponentDefaults sets the attributes Read doesn't refresh to
+// their defaults, so the first plan after an import doesn't update them.
+func setImportedComponentDefaults(ctx context.Context, state *tfsdk.State) diag.Diagnostics {
+	var diags diag.Diagnostics
+	diags.Append(state.SetAttribute(ctx, path.Root("show_uptime"), true)...)
+	diags.Append(state.SetAttribute(ctx, path.Root("auto_create_group"), false)...)
+	diags.Append(state.SetAttribute(ctx, path.Root("prevent_duplicate_name"), false)...)
+	diags.Append(state.SetAttribute(ctx, path.Root("deletion_policy"), deletionPolicyDelete)...)
+
+	return diags
+}
+
+// componentStartDate returns the date part of the start date of a component,
+// which the API reports as a timestamp.
+func componentStartDate(startDate *string) types.String {
+	if startDate == nil {
+		return types.StringNull()
+	}
+
+	if t, err := time.Parse(time.RFC3339, *startDate); err == nil {
+		return types.StringValue(t.UTC().Format(time.DateOnly))
+	}
+
+	return types.StringValue(*startDate)
+}
+
+// newComponentTranslations converts the translations of the plan to the API
+// representation. Translations are always sent so removed ones are cleared.
+func newComponentTranslations(models map[string]componentTranslationModel) *componentTranslations {
+	translations := &componentTranslations{
+		Name:        make(map[string]string),
+		Description: make(map[string]string),
+	}
+
+	for locale, model := range models {
+		if !model.Name.IsNull() {
+			translations.Name[locale] = model.Name.ValueString()
+		}
+		if !model.Description.IsNull() {
+			translations.Description[locale] = model.Description.ValueString()
+		}
+	}
+
+	return translations
+}
+
+// componentTranslationModels converts the translations returned by the API to
+// the schema, ignoring blank texts.
+func componentTranslationModels(translations *componentTranslations) map[string]componentTranslationModel {
+	if translations == nil {
+		return nil
+	}
+
+	models := make(map[string]componentTranslationModel)
+	for locale, name := range translations.Name {
+		if name == "" {
+			continue
+		}
+		model := models[locale]
+		model.Name = types.StringValue(name)
+		models[locale] = model
+	}
+	for locale, description := range translations.Description {
+		if description == "" {
+			continue
+		}
+		model := models[locale]
+		model.Description = types.StringValue(description)
+		models[locale] = model
+	}
+
+	if len(models) == 0 {
+		return nil
+	}
+
+	return models
+}
+
+// refreshComponentTranslations returns the translations returned by the API,
+// unless they only differ from the current ones by empty translations or
+// blank texts, which the API doesn't keep.
+func refreshComponentTranslations(current map[string]componentTranslationModel, remote *componentTranslations) map[string]componentTranslationModel {
+	refreshed := componentTranslationModels(remote)
+	if reflect.DeepEqual(componentTranslationModels(newComponentTranslations(current)), refreshed) {
+		return current
+	}
+
+	return refreshed
+}
+
+// resolveComponentGroup points a component request at the existing group
+// named in the request, failing when there is none unless autoCreate lets
+// the API create it. groupPath is the attribute naming the group.
+func resolveComponentGroup(ctx context.Context, provider *instatusProviderData, pageID string, autoCreate bool, groupPath path.Path, item *componentRequest) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if item.Group == nil || item.GroupId != nil {
+		return diags
+	}
+
+	groups, err := provider.components.Groups(ctx, pageID)
+	if err != nil && !autoCreate {
+		// Sending the name alone would let the API create the group.
+		addAPIError(
+			&diags,
+			"Unable to Resolve Component Group",
+			"Could not check that group "+*item.Group+" exists on Instatus page ID "+pageID,
+			err,
+		)
+		return diags
+	}
+	if err != nil {
+		tflog.Debug(ctx, "Unable to list the component groups of the Instatus page, leaving the group to the API", map[string]interface{}{
+			"page_id": pageID,
+			"error":   err.Error(),
+		})
+		return diags
+	}
+
+	for id, name := range groups {
+		if name == *item.Group {
+			groupID := id
+			item.GroupId = &groupID
+			return diags
+		}
+	}
+
+	if !autoCreate {
+		diags.AddAttributeError(
+			groupPath,
+			"Component Group Not Found",
+			"No component group named "+*item.Group+" exists on page "+pageID+". "+
+				"Set auto_create_group to true to create it along with the component.",
+		)
+	}
+
+	return diags
+}
+
+// applyComponentGroup sets the group of a component request from the group
+// attribute, when it is configured.
+func applyComponentGroup(ctx context.Context, group types.Object, item *componentRequest) diag.Diagnostics {
+	if group.IsNull() || group.IsUnknown() {
+		return nil
+	}
+
+	var model componentGroupModel
+	diags := group.As(ctx, &model, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return diags
+	}
+
+	grouped := true
+	item.Grouped = &grouped
+	item.Group = trimmedStringPointer(model.Name)
+	item.GroupId = knownStringPointer(model.ID)
+
+	return diags
+}
+
+// componentGroupValue converts the group returned by the API to the schema.
+func componentGroupValue(group is.Group) types.Object {
+	if group.Id == nil && group.Name == nil {
+		return types.ObjectNull(componentGroupAttrTypes)
+	}
+
+	return types.ObjectValueMust(componentGroupAttrTypes, map[string]attr.Value{
+		"id":   types.StringPointerValue(group.Id),
+		"name": types.StringPointerValue(group.Name),
+	})
+}
+
+// refreshComponentGroup converts the group returned by the API to the schema,
+// keeping the current group name when it only differs by surrounding
+// whitespace.
+func refreshComponentGroup(current types.Object, group is.Group) types.Object {
+	refreshed := componentGroupValue(group)
+	if current.IsNull() || current.IsUnknown() || refreshed.IsNull() {
+		return refreshed
+	}
+
+	name, ok := current.Attributes()["name"].(types.String)
+	if !ok {
+		return refreshed
+	}
+
+	return types.ObjectValueMust(componentGroupAttrTypes, map[string]attr.Value{
+		"id":   types.StringPointerValue(group.Id),
+		"name": keepUntrimmed(name, group.Name),
+	})
+}
+
+// componentURL returns the URL of a component on the public status page,
+// keeping the current value when the page cannot be resolved.
+func (r *componentResource) componentURL(ctx context.Context, pageID string, componentID string, current types.String) types.String {
+	pageURL, err := r.provider.pageURLs.Get(r.provider.Client(ctx), pageID)
+	if err != nil {
+		tflog.Debug(ctx, "Unable to resolve the public URL of the Instatus page", map[string]interface{}{
+			"page_id": pageID,
+			"error":   err.Error(),
+		})
+		if current.IsUnknown() {
+			return types.StringNull()
+		}
+		return current
+	}
+
+	return types.StringValue(pageURL + "/#" + componentID)
+}
+
+// componentNameValidators returns the validators of the names of components
+// and groups, rejected by the API when too long, blank or spanning lines.
+func componentNameValidators() []validator.String {
+	return []validator.String{
+		stringvalidator.LengthBetween(1, componentNameMaxLength),
+		stringvalidator.RegexMatches(regexp.MustCompile(`\S`), "must not be blank"),
+		stringvalidator.RegexMatches(regexp.MustCompile(`^[^\x00-\x1f\x7f]*$`), "must not contain control characters such as line breaks"),
+	}
+}
+
+// componentDescriptionValidators returns the validators of the descriptions
+// of components, rejected by the API when too long.
+func componentDescriptionValidators() []validator.String {
+	return []validator.String{
+		stringvalidator.LengthAtMost(componentDescriptionMaxLength),
+	}
+}
+
+// trimmedStringPointer returns the value of a string attribute without
+// surrounding whitespace, as the API stores it.
+func trimmedStringPointer(value types.String) *string {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+
+	trimmed := strings.TrimSpace(value.ValueString())
+	return &trimmed
+}
+
+// knownStringPointer returns the value of a string attribute, or nil when it
+// is null or unknown, like the computed attributes not configured.
+func knownStringPointer(value types.String) *string {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+
+	return value.ValueStringPointer()
+}
+
+// keepUntrimmed returns the value stored by the API, unless it only differs
+// from the current value by surrounding whitespace, so names copied with
+// stray spaces don't show a perpetual diff.
+func keepUntrimmed(current types.String, remote *string) types.String {
+	if remote != nil && !current.IsNull() && !current.IsUnknown() && strings.TrimSpace(current.ValueString()) == *remote {
+		return current
+	}
+
+	return types.StringPointerValue(remote)
+}
+
+// setComponentIdentity sets the identity of a component, when Terraform
+// supports resource identities.
+func setComponentIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, pageID types.String, id types.String, diags *diag.Diagnostics) {
+	if identity == nil {
+		return
+	}
+
+	diags.Append(identity.Set(ctx, componentIdentityModel{PageID: pageID, ID: id})...)
+}
+
+// componentAttributes returns the attributes of a component not managed yet,
+// as Read refreshes an imported one.
+func (r *componentResource) componentAttributes(ctx context.Context, pageID string, component component) map[string]interface{} {
+	id := types.StringPointerValue(component.ID)
+
+	return map[string]interface{}{
+		"id":                     id,
+		"page_id":                types.StringValue(pageID),
+		"name":                   types.StringPointerValue(component.Name),
+		"description":            types.StringPointerValue(component.Description),
+		"show_uptime":            types.BoolValue(component.ShowUptime == nil || *component.ShowUptime),
+		"grouped":                types.BoolValue(component.Group.Id != nil || component.Group.Name != nil),
+		"group_name":             types.StringPointerValue(component.Group.Name),
+		"prevent_duplicate_name": types.BoolValue(false),
+		"auto_create_group":      types.BoolValue(false),
+		"archived":               types.BoolValue(component.Archived != nil && *component.Archived),
+		"start_date":             componentStartDate(component.StartDate),
+		"translations":           componentTranslationModels(component.Translations),
+		"unique_email":           types.StringPointerValue(component.UniqueEmail),
+		"webhook_url":            types.StringPointerValue(component.WebhookURL),
+		"webhook_token":          types.StringPointerValue(component.WebhookToken),
+		"url":                    r.componentURL(ctx, pageID, id.ValueString(), types.StringNull()),
+		"deletion_policy":        types.StringValue(deletionPolicyDelete),
+	}
 }
@@ -0,0 +1,903 @@
+package instatus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	is "github.com/brunoscota/instatus-client-go"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const testPageID = "page"
+
+// fakeClient is an in-memory Instatus API, grouping components the way the
+// API does. The template methods of the embedded interface are not
+// implemented.
+type fakeClient struct {
+	instatusClient
+
+	components map[string]*component
+	groups     map[string]string
+	creates    []componentRequest
+	updates    []componentRequest
+	deletes    []string
+	// listErr fails the component list calls when set.
+	listErr error
+	// err fails every component call when set.
+	err error
+}
+
+// newFakeClient returns a page with the Backend and Frontend groups, and the
+// api component in the Backend group.
+func newFakeClient() *fakeClient {
+	c := &fakeClient{
+		components: make(map[string]*component),
+		groups:     map[string]string{"backend-id": "Backend", "frontend-id": "Frontend"},
+	}
+	c.components["api"] = &component{ComponentFull: is.ComponentFull{
+		ID:        stringPointer("api"),
+		Component: is.Component{Name: stringPointer("API"), ShowUptime: boolPointer(true)},
+		Group:     is.Group{Id: stringPointer("backend-id"), Name: stringPointer("Backend")},
+	}}
+
+	return c
+}
+
+func (c *fakeClient) ListPages() ([]page, error) {
+	return []page{{ID: stringPointer(testPageID), Subdomain: stringPointer("example")}}, nil
+}
+
+func (c *fakeClient) ListComponents(_ string) ([]component, error) {
+	if c.listErr != nil {
+		return nil, c.listErr
+	}
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	var components []component
+	for id, name := range c.groups {
+		components = append(components, component{
+			ComponentFull: is.ComponentFull{ID: stringPointer(id), Component: is.Component{Name: stringPointer(name)}},
+			IsParent:      boolPointer(true),
+		})
+	}
+	for _, component := range c.components {
+		components = append(components, *component)
+	}
+
+	return components, nil
+}
+
+func (c *fakeClient) CreateComponent(pageID string, item *componentRequest) (*component, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	c.creates = append(c.creates, *item)
+	id := fmt.Sprintf("component-%d", len(c.creates))
+	// The uptime history of new components starts when they are created.
+	c.components[id] = &component{
+		ComponentFull: is.ComponentFull{ID: &id},
+		StartDate:     stringPointer("2026-01-01T00:00:00.000Z"),
+	}
+
+	return c.apply(pageID, id, item)
+}
+
+func (c *fakeClient) GetComponent(_ string, componentID string) (*component, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	component, ok := c.components[componentID]
+	if !ok {
+		return nil, &apiError{StatusCode: 404, Method: "GET", Path: componentEndpoint(testPageID, componentID)}
+	}
+	copied := *component
+
+	return &copied, nil
+}
+
+func (c *fakeClient) UpdateComponent(pageID string, componentID string, item *componentRequest) (*component, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	c.updates = append(c.updates, *item)
+
+	return c.apply(pageID, componentID, item)
+}
+
+func (c *fakeClient) DeleteComponent(pageID string, componentID string) error {
+	if c.err != nil {
+		return c.err
+	}
+	if _, ok := c.components[componentID]; !ok {
+		return &apiError{StatusCode: 404, Method: "DELETE", Path: componentEndpoint(pageID, componentID)}
+	}
+	c.deletes = append(c.deletes, componentID)
+	delete(c.components, componentID)
+
+	return nil
+}
+
+// apply writes a create or update request to a component.
+func (c *fakeClient) apply(pageID string, componentID string, item *componentRequest) (*component, error) {
+	component, ok := c.components[componentID]
+	if !ok {
+		return nil, &apiError{StatusCode: 404, Method: "PUT", Path: componentEndpoint(pageID, componentID)}
+	}
+
+	if item.Name != nil {
+		component.Name = item.Name
+	}
+	if item.Description != nil {
+		component.Description = item.Description
+	}
+	if item.ShowUptime != nil {
+		component.ShowUptime = item.ShowUptime
+	}
+	if item.Archived != nil {
+		component.Archived = item.Archived
+	}
+	if item.StartDate != nil {
+		component.StartDate = item.StartDate
+	}
+	switch {
+	case item.Grouped != nil && !*item.Grouped:
+		component.Group = is.Group{}
+	case item.GroupId != nil:
+		name := c.groups[*item.GroupId]
+		component.Group = is.Group{Id: item.GroupId, Name: &name}
+	case item.Group != nil:
+		// The API creates the groups it doesn't know.
+		id := fmt.Sprintf("group-%d", len(c.groups))
+		c.groups[id] = *item.Group
+		component.Group = is.Group{Id: &id, Name: item.Group}
+	}
+
+	return c.GetComponent(pageID, componentID)
+}
+
+func stringPointer(s string) *string { return &s }
+
+func boolPointer(b bool) *bool { return &b }
+
+// newTestComponentResource returns a component resource using client.
+func newTestComponentResource(client instatusClient) *componentResource {
+	newClient := func(context.Context) instatusClient { return client }
+
+	return &componentResource{
+		provider: &instatusProviderData{
+			newClient:  newClient,
+			components: &componentBatch{newClient: newClient},
+			pageURLs:   &pageURLs{},
+			quota:      &quotaMonitor{},
+		},
+	}
+}
+
+// testComponentModel returns the state of the api component in the Backend
+// group, referred to by group_name.
+func testComponentModel() componentResourceModel {
+	return componentResourceModel{
+		ID:                   types.StringValue("api"),
+		Name:                 types.StringValue("API"),
+		PageID:               types.StringValue(testPageID),
+		Description:          types.StringNull(),
+		ShowUptime:           types.BoolValue(true),
+		Grouped:              types.BoolValue(true),
+		GroupName:            types.StringValue("Backend"),
+		GroupId:              types.StringNull(),
+		Group:                types.ObjectNull(componentGroupAttrTypes),
+		PreventDuplicateName: types.BoolValue(false),
+		AutoCreateGroup:      types.BoolValue(false),
+		Archived:             types.BoolValue(false),
+		StartDate:            types.StringNull(),
+		UniqueEmail:          types.StringNull(),
+		WebhookURL:           types.StringNull(),
+		WebhookToken:         types.StringNull(),
+		URL:                  types.StringValue("https://example.instatus.com/#api"),
+		DeletionPolicy:       types.StringValue(deletionPolicyDelete),
+		MaxRetries:           types.Int64Null(),
+		Timeouts: timeouts.Value{Object: types.ObjectNull(map[string]attr.Type{
+			"create": types.StringType,
+			"update": types.StringType,
+			"delete": types.StringType,
+		})},
+	}
+}
+
+// updateComponent runs an in-place update of the api component from the
+// testComponentModel state to plan.
+func updateComponent(t *testing.T, client *fakeClient, plan componentResourceModel) (componentResourceModel, resource.UpdateResponse) {
+	t.Helper()
+	ctx := context.Background()
+	r := newTestComponentResource(client)
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	req := resource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema},
+		State: tfsdk.State{Schema: schemaResp.Schema},
+	}
+	if diags := req.Plan.Set(ctx, plan); diags.HasError() {
+		t.Fatalf("setting plan: %v", diags)
+	}
+	if diags := req.State.Set(ctx, testComponentModel()); diags.HasError() {
+		t.Fatalf("setting state: %v", diags)
+	}
+
+	resp := resource.UpdateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+	}
+	r.Update(ctx, req, &resp)
+
+	var state componentResourceModel
+	if !resp.Diagnostics.HasError() {
+		if diags := resp.State.Get(ctx, &state); diags.HasError() {
+			t.Fatalf("getting state: %v", diags)
+		}
+	}
+
+	return state, resp
+}
+
+// createComponent runs the creation of a component planned as plan.
+func createComponent(t *testing.T, client *fakeClient, plan componentResourceModel) (componentResourceModel, resource.CreateResponse) {
+	t.Helper()
+	ctx := context.Background()
+	r := newTestComponentResource(client)
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	req := resource.CreateRequest{Plan: tfsdk.Plan{Schema: schemaResp.Schema}}
+	if diags := req.Plan.Set(ctx, plan); diags.HasError() {
+		t.Fatalf("setting plan: %v", diags)
+	}
+
+	resp := resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+	}
+	r.Create(ctx, req, &resp)
+
+	var state componentResourceModel
+	if !resp.Diagnostics.HasError() {
+		if diags := resp.State.Get(ctx, &state); diags.HasError() {
+			t.Fatalf("getting state: %v", diags)
+		}
+	}
+
+	return state, resp
+}
+
+// testNewComponentModel returns the plan of a new ungrouped component, its
+// computed attributes unknown.
+func testNewComponentModel() componentResourceModel {
+	plan := testComponentModel()
+	plan.ID = types.StringUnknown()
+	plan.Name = types.StringValue("Website")
+	plan.Grouped = types.BoolValue(false)
+	plan.GroupName = types.StringNull()
+	plan.StartDate = types.StringUnknown()
+	plan.UniqueEmail = types.StringUnknown()
+	plan.WebhookURL = types.StringUnknown()
+	plan.WebhookToken = types.StringUnknown()
+	plan.URL = types.StringUnknown()
+
+	return plan
+}
+
+// assertNoStartDate fails the test when the body of item sets startDate.
+func assertNoStartDate(t *testing.T, item componentRequest) {
+	t.Helper()
+
+	body, err := json.Marshal(item)
+	if err != nil {
+		t.Fatalf("encoding request: %v", err)
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		t.Fatalf("decoding request: %v", err)
+	}
+	if startDate, ok := fields["startDate"]; ok {
+		t.Errorf("expected no startDate in the request body, got %q", startDate)
+	}
+}
+
+func TestComponentCreateOmitsUnsetStartDate(t *testing.T) {
+	client := newFakeClient()
+
+	state, resp := createComponent(t, client, testNewComponentModel())
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	if len(client.creates) != 1 {
+		t.Fatalf("expected 1 create, got %d", len(client.creates))
+	}
+	assertNoStartDate(t, client.creates[0])
+	if state.StartDate.ValueString() != "2026-01-01" {
+		t.Errorf("expected the start_date set by the API, got %s", state.StartDate)
+	}
+}
+
+func TestComponentCreateSendsStartDate(t *testing.T) {
+	client := newFakeClient()
+	plan := testNewComponentModel()
+	plan.StartDate = types.StringValue("2020-05-01")
+
+	state, resp := createComponent(t, client, plan)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	if got := stringValue(client.creates[0].StartDate); got != "2020-05-01" {
+		t.Errorf("expected startDate 2020-05-01 in the request, got %q", got)
+	}
+	if state.StartDate.ValueString() != "2020-05-01" {
+		t.Errorf("expected start_date 2020-05-01, got %s", state.StartDate)
+	}
+}
+
+func TestComponentUpdateOmitsUnsetStartDate(t *testing.T) {
+	client := newFakeClient()
+	plan := testComponentModel()
+	plan.Description = types.StringValue("Public API")
+	plan.StartDate = types.StringUnknown()
+
+	_, resp := updateComponent(t, client, plan)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	if len(client.updates) != 1 {
+		t.Fatalf("expected 1 update, got %d", len(client.updates))
+	}
+	assertNoStartDate(t, client.updates[0])
+}
+
+func TestComponentUpdateMovesToExistingGroupByName(t *testing.T) {
+	client := newFakeClient()
+	plan := testComponentModel()
+	plan.GroupName = types.StringValue("Frontend")
+
+	state, resp := updateComponent(t, client, plan)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	if len(client.updates) != 1 {
+		t.Fatalf("expected 1 update, got %d", len(client.updates))
+	}
+	if got := stringValue(client.updates[0].GroupId); got != "frontend-id" {
+		t.Errorf("expected the update to move the component to group frontend-id, got %q", got)
+	}
+	if got := stringValue(client.components["api"].Group.Id); got != "frontend-id" {
+		t.Errorf("expected the component to be in group frontend-id, got %q", got)
+	}
+	if len(client.groups) != 2 {
+		t.Errorf("expected no group to be created, got %v", client.groups)
+	}
+
+	if state.ID.ValueString() != "api" {
+		t.Errorf("expected the component to be updated in place, got ID %s", state.ID)
+	}
+	if state.GroupName.ValueString() != "Frontend" || !state.GroupId.IsNull() {
+		t.Errorf("expected group_name Frontend and a null group_id, got %s and %s", state.GroupName, state.GroupId)
+	}
+}
+
+func TestComponentUpdateMovesToGroupByID(t *testing.T) {
+	client := newFakeClient()
+	plan := testComponentModel()
+	plan.GroupName = types.StringNull()
+	plan.GroupId = types.StringValue("frontend-id")
+
+	state, resp := updateComponent(t, client, plan)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	if got := stringValue(client.components["api"].Group.Name); got != "Frontend" {
+		t.Errorf("expected the component to be in group Frontend, got %q", got)
+	}
+	if state.GroupId.ValueString() != "frontend-id" || !state.GroupName.IsNull() {
+		t.Errorf("expected group_id frontend-id and a null group_name, got %s and %s", state.GroupId, state.GroupName)
+	}
+}
+
+func TestComponentUpdateMovesToGroupAttribute(t *testing.T) {
+	client := newFakeClient()
+	plan := testComponentModel()
+	plan.GroupName = types.StringNull()
+	plan.Group = types.ObjectValueMust(componentGroupAttrTypes, map[string]attr.Value{
+		"id":   types.StringValue("frontend-id"),
+		"name": types.StringNull(),
+	})
+
+	state, resp := updateComponent(t, client, plan)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	want := componentGroupValue(is.Group{Id: stringPointer("frontend-id"), Name: stringPointer("Frontend")})
+	if !state.Group.Equal(want) {
+		t.Errorf("expected group %s, got %s", want, state.Group)
+	}
+}
+
+func TestComponentUpdateTrimsGroupAttributeName(t *testing.T) {
+	client := newFakeClient()
+	plan := testComponentModel()
+	plan.GroupName = types.StringNull()
+	plan.Group = types.ObjectValueMust(componentGroupAttrTypes, map[string]attr.Value{
+		"id":   types.StringUnknown(),
+		"name": types.StringValue(" Frontend "),
+	})
+
+	state, resp := updateComponent(t, client, plan)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	if got := stringValue(client.updates[0].Group); got != "Frontend" {
+		t.Errorf("expected the group name Frontend to be sent, got %q", got)
+	}
+	want := types.ObjectValueMust(componentGroupAttrTypes, map[string]attr.Value{
+		"id":   types.StringValue("frontend-id"),
+		"name": types.StringValue(" Frontend "),
+	})
+	if !state.Group.Equal(want) {
+		t.Errorf("expected group %s, got %s", want, state.Group)
+	}
+}
+
+func TestComponentUpdateMissingGroupFails(t *testing.T) {
+	client := newFakeClient()
+	plan := testComponentModel()
+	plan.GroupName = types.StringValue("Payments")
+
+	_, resp := updateComponent(t, client, plan)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error for a missing group")
+	}
+	if len(client.updates) != 0 {
+		t.Errorf("expected no update, got %d", len(client.updates))
+	}
+	if got := stringValue(client.components["api"].Group.Id); got != "backend-id" {
+		t.Errorf("expected the component to stay in group backend-id, got %q", got)
+	}
+}
+
+func TestComponentUpdateCreatesMissingGroup(t *testing.T) {
+	client := newFakeClient()
+	plan := testComponentModel()
+	plan.GroupName = types.StringValue("Payments")
+	plan.AutoCreateGroup = types.BoolValue(true)
+
+	state, resp := updateComponent(t, client, plan)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	if client.updates[0].GroupId != nil {
+		t.Errorf("expected the group to be left to the API, got group ID %q", *client.updates[0].GroupId)
+	}
+	if got := stringValue(client.components["api"].Group.Name); got != "Payments" {
+		t.Errorf("expected the component to be in group Payments, got %q", got)
+	}
+	if state.GroupName.ValueString() != "Payments" {
+		t.Errorf("expected group_name Payments, got %s", state.GroupName)
+	}
+}
+
+func TestComponentUpdateUnlistedGroupFails(t *testing.T) {
+	client := newFakeClient()
+	client.listErr = &apiError{StatusCode: 503, Method: "GET", Path: "/page/components"}
+	plan := testComponentModel()
+	plan.GroupName = types.StringValue("Frontend")
+
+	_, resp := updateComponent(t, client, plan)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error when the groups can't be listed")
+	}
+	if len(client.updates) != 0 {
+		t.Errorf("expected no update, got %d", len(client.updates))
+	}
+}
+
+func TestComponentUpdateUnlistedGroupAutoCreated(t *testing.T) {
+	client := newFakeClient()
+	client.listErr = &apiError{StatusCode: 503, Method: "GET", Path: "/page/components"}
+	plan := testComponentModel()
+	plan.GroupName = types.StringValue("Payments")
+	plan.AutoCreateGroup = types.BoolValue(true)
+
+	_, resp := updateComponent(t, client, plan)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if got := stringValue(client.updates[0].Group); got != "Payments" {
+		t.Errorf("expected the group name to be left to the API, got %q", got)
+	}
+}
+
+func TestComponentUpdateRemovesGroup(t *testing.T) {
+	client := newFakeClient()
+	plan := testComponentModel()
+	plan.Grouped = types.BoolValue(false)
+	plan.GroupName = types.StringNull()
+
+	state, resp := updateComponent(t, client, plan)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	if group := client.components["api"].Group; group.Id != nil || group.Name != nil {
+		t.Errorf("expected the component to be ungrouped, got group %q", stringValue(group.Name))
+	}
+	if !state.GroupName.IsNull() || state.Grouped.ValueBool() {
+		t.Errorf("expected no group in state, got grouped %s and group_name %s", state.Grouped, state.GroupName)
+	}
+}
+
+func TestComponentReadDetectsGroupMove(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeClient()
+	client.components["api"].Group = is.Group{Id: stringPointer("frontend-id"), Name: stringPointer("Frontend")}
+	r := newTestComponentResource(client)
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	req := resource.ReadRequest{State: tfsdk.State{Schema: schemaResp.Schema}}
+	if diags := req.State.Set(ctx, testComponentModel()); diags.HasError() {
+		t.Fatalf("setting state: %v", diags)
+	}
+	resp := resource.ReadResponse{State: req.State}
+	r.Read(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var state componentResourceModel
+	if diags := resp.State.Get(ctx, &state); diags.HasError() {
+		t.Fatalf("getting state: %v", diags)
+	}
+	if state.GroupName.ValueString() != "Frontend" {
+		t.Errorf("expected group_name Frontend after the move, got %s", state.GroupName)
+	}
+	if !state.GroupId.IsNull() {
+		t.Errorf("expected group_id to stay null, got %s", state.GroupId)
+	}
+}
+
+// importComponent imports the component identified by id and refreshes it,
+// as terraform import does.
+func importComponent(t *testing.T, client *fakeClient, id string) componentResourceModel {
+	t.Helper()
+	ctx := context.Background()
+	r := newTestComponentResource(client)
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	importResp := resource.ImportStateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+	}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: id}, &importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected import error: %v", importResp.Diagnostics)
+	}
+
+	readResp := resource.ReadResponse{State: importResp.State}
+	r.Read(ctx, resource.ReadRequest{State: importResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected read error: %v", readResp.Diagnostics)
+	}
+
+	var state componentResourceModel
+	if diags := readResp.State.Get(ctx, &state); diags.HasError() {
+		t.Fatalf("getting state: %v", diags)
+	}
+
+	return state
+}
+
+func TestComponentImportSetsDefaults(t *testing.T) {
+	state := importComponent(t, newFakeClient(), testPageID+"/api")
+
+	if state.ID.ValueString() != "api" || state.PageID.ValueString() != testPageID {
+		t.Errorf("expected component api of page %s, got %s of page %s", testPageID, state.ID, state.PageID)
+	}
+	if state.Name.ValueString() != "API" || state.GroupName.ValueString() != "Backend" {
+		t.Errorf("expected the name and group of the component, got %s and %s", state.Name, state.GroupName)
+	}
+	if state.DeletionPolicy.ValueString() != deletionPolicyDelete {
+		t.Errorf("expected deletion_policy %s, got %s", deletionPolicyDelete, state.DeletionPolicy)
+	}
+	if state.AutoCreateGroup.IsNull() || state.AutoCreateGroup.ValueBool() {
+		t.Errorf("expected auto_create_group false, got %s", state.AutoCreateGroup)
+	}
+	if state.PreventDuplicateName.IsNull() || state.PreventDuplicateName.ValueBool() {
+		t.Errorf("expected prevent_duplicate_name false, got %s", state.PreventDuplicateName)
+	}
+	if !state.ShowUptime.ValueBool() {
+		t.Errorf("expected show_uptime true, got %s", state.ShowUptime)
+	}
+}
+
+// readComponent refreshes the testComponentModel state of the api component.
+func readComponent(t *testing.T, r *componentResource) (componentResourceModel, resource.ReadResponse) {
+	t.Helper()
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	req := resource.ReadRequest{State: tfsdk.State{Schema: schemaResp.Schema}}
+	if diags := req.State.Set(ctx, testComponentModel()); diags.HasError() {
+		t.Fatalf("setting state: %v", diags)
+	}
+	resp := resource.ReadResponse{State: req.State}
+	r.Read(ctx, req, &resp)
+
+	var state componentResourceModel
+	if !resp.Diagnostics.HasError() && !resp.State.Raw.IsNull() {
+		if diags := resp.State.Get(ctx, &state); diags.HasError() {
+			t.Fatalf("getting state: %v", diags)
+		}
+	}
+
+	return state, resp
+}
+
+// deleteComponent destroys the api component, whose state is
+// testComponentModel with the given deletion_policy.
+func deleteComponent(t *testing.T, client *fakeClient, deletionPolicy string) resource.DeleteResponse {
+	t.Helper()
+	ctx := context.Background()
+	r := newTestComponentResource(client)
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	state := testComponentModel()
+	state.DeletionPolicy = types.StringValue(deletionPolicy)
+	req := resource.DeleteRequest{State: tfsdk.State{Schema: schemaResp.Schema}}
+	if diags := req.State.Set(ctx, state); diags.HasError() {
+		t.Fatalf("setting state: %v", diags)
+	}
+	resp := resource.DeleteResponse{State: req.State}
+	r.Delete(ctx, req, &resp)
+
+	return resp
+}
+
+func TestComponentCreate(t *testing.T) {
+	tests := map[string]struct {
+		err       error
+		wantError bool
+	}{
+		"created": {},
+		"rejected": {
+			err:       &apiError{StatusCode: 422, Method: "POST", Path: "/v1/page/components", Message: "name is too long"},
+			wantError: true,
+		},
+		"unreachable": {
+			err:       &apiError{StatusCode: 503, Method: "POST", Path: "/v1/page/components"},
+			wantError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := newFakeClient()
+			client.err = test.err
+
+			state, resp := createComponent(t, client, testNewComponentModel())
+			if resp.Diagnostics.HasError() != test.wantError {
+				t.Fatalf("expected error %t, got %v", test.wantError, resp.Diagnostics)
+			}
+			if test.wantError {
+				if len(client.components) != 1 {
+					t.Errorf("expected no component to be created, got %d components", len(client.components))
+				}
+				return
+			}
+
+			if state.ID.ValueString() != "component-1" || state.Name.ValueString() != "Website" {
+				t.Errorf("expected component-1 named Website in state, got %s named %s", state.ID, state.Name)
+			}
+			if state.URL.ValueString() != "https://example.instatus.com/#component-1" {
+				t.Errorf("expected the status page URL of the component, got %s", state.URL)
+			}
+		})
+	}
+}
+
+func TestComponentRead(t *testing.T) {
+	tests := map[string]struct {
+		// change alters the component outside of Terraform.
+		change       func(client *fakeClient)
+		tolerate     bool
+		wantError    bool
+		wantWarning  bool
+		wantRemoved  bool
+		wantName     string
+		wantArchived bool
+	}{
+		"unchanged": {
+			wantName: "API",
+		},
+		"changed outside of Terraform": {
+			change: func(client *fakeClient) {
+				client.components["api"].Name = stringPointer("Public API")
+				client.components["api"].Archived = boolPointer(true)
+			},
+			wantName:     "Public API",
+			wantArchived: true,
+		},
+		"deleted outside of Terraform": {
+			change: func(client *fakeClient) {
+				delete(client.components, "api")
+			},
+			wantRemoved: true,
+		},
+		"API failing": {
+			change: func(client *fakeClient) {
+				client.err = &apiError{StatusCode: 500, Method: "GET", Path: "/v1/page/components/api"}
+			},
+			wantError: true,
+		},
+		"API failing with tolerate_read_errors": {
+			change: func(client *fakeClient) {
+				client.err = &apiError{StatusCode: 500, Method: "GET", Path: "/v1/page/components/api"}
+			},
+			tolerate:    true,
+			wantWarning: true,
+			wantName:    "API",
+		},
+		"forbidden with tolerate_read_errors": {
+			change: func(client *fakeClient) {
+				client.err = &apiError{StatusCode: 403, Method: "GET", Path: "/v1/page/components/api"}
+			},
+			tolerate:  true,
+			wantError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := newFakeClient()
+			if test.change != nil {
+				test.change(client)
+			}
+			r := newTestComponentResource(client)
+			r.provider.tolerateReadErrors = test.tolerate
+
+			state, resp := readComponent(t, r)
+			if resp.Diagnostics.HasError() != test.wantError {
+				t.Fatalf("expected error %t, got %v", test.wantError, resp.Diagnostics)
+			}
+			if (resp.Diagnostics.WarningsCount() > 0) != test.wantWarning {
+				t.Errorf("expected warning %t, got %v", test.wantWarning, resp.Diagnostics)
+			}
+			if test.wantError {
+				return
+			}
+			if resp.State.Raw.IsNull() != test.wantRemoved {
+				t.Fatalf("expected removed from state %t, got state %v", test.wantRemoved, resp.State.Raw)
+			}
+			if test.wantRemoved {
+				return
+			}
+
+			if state.Name.ValueString() != test.wantName {
+				t.Errorf("expected name %s, got %s", test.wantName, state.Name)
+			}
+			if state.Archived.ValueBool() != test.wantArchived {
+				t.Errorf("expected archived %t, got %s", test.wantArchived, state.Archived)
+			}
+		})
+	}
+}
+
+func TestComponentUpdate(t *testing.T) {
+	tests := map[string]struct {
+		err       error
+		wantError bool
+	}{
+		"updated": {},
+		"deleted outside of Terraform": {
+			err:       &apiError{StatusCode: 404, Method: "PUT", Path: "/v1/page/components/api"},
+			wantError: true,
+		},
+		"conflict": {
+			err:       &apiError{StatusCode: 409, Method: "PUT", Path: "/v1/page/components/api"},
+			wantError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := newFakeClient()
+			client.err = test.err
+
+			plan := testComponentModel()
+			plan.Name = types.StringValue("Public API")
+			plan.Description = types.StringValue("REST API")
+
+			state, resp := updateComponent(t, client, plan)
+			if resp.Diagnostics.HasError() != test.wantError {
+				t.Fatalf("expected error %t, got %v", test.wantError, resp.Diagnostics)
+			}
+			if test.wantError {
+				return
+			}
+
+			if len(client.updates) == 0 || stringValue(client.updates[0].Name) != "Public API" {
+				t.Fatalf("expected the new name to be sent, got %v", client.updates)
+			}
+			if state.Name.ValueString() != "Public API" || state.Description.ValueString() != "REST API" {
+				t.Errorf("expected the new name and description in state, got %s and %s", state.Name, state.Description)
+			}
+		})
+	}
+}
+
+func TestComponentDelete(t *testing.T) {
+	tests := map[string]struct {
+		deletionPolicy string
+		err            error
+		wantError      bool
+		wantDeleted    bool
+		wantArchived   bool
+	}{
+		"delete": {
+			deletionPolicy: deletionPolicyDelete,
+			wantDeleted:    true,
+		},
+		"archive": {
+			deletionPolicy: deletionPolicyArchive,
+			wantArchived:   true,
+		},
+		"abandon": {
+			deletionPolicy: deletionPolicyAbandon,
+		},
+		"API failing": {
+			deletionPolicy: deletionPolicyDelete,
+			err:            &apiError{StatusCode: 502, Method: "DELETE", Path: "/v1/page/components/api"},
+			wantError:      true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := newFakeClient()
+			client.err = test.err
+
+			resp := deleteComponent(t, client, test.deletionPolicy)
+			if resp.Diagnostics.HasError() != test.wantError {
+				t.Fatalf("expected error %t, got %v", test.wantError, resp.Diagnostics)
+			}
+
+			component, ok := client.components["api"]
+			if ok == test.wantDeleted {
+				t.Errorf("expected deleted %t, got components %v", test.wantDeleted, client.components)
+			}
+			if ok && (component.Archived != nil && *component.Archived) != test.wantArchived {
+				t.Errorf("expected archived %t, got %v", test.wantArchived, component.Archived)
+			}
+		})
+	}
+}
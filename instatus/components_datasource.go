@@ -0,0 +1,150 @@
+package instatus
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &componentsDataSource{}
+	_ datasource.DataSourceWithConfigure = &componentsDataSource{}
+)
+
+// NewComponentsDataSource is a helper function to simplify the provider implementation.
+func NewComponentsDataSource() datasource.DataSource {
+	return &componentsDataSource{}
+}
+
+// componentsDataSource lists the components of a page.
+type componentsDataSource struct {
+	provider *instatusProviderData
+}
+
+// componentsDataSourceModel maps the data source schema data.
+type componentsDataSourceModel struct {
+	ID         types.String                     `tfsdk:"id"`
+	PageID     types.String                     `tfsdk:"page_id"`
+	Components []componentsDataSourceEntryModel `tfsdk:"components"`
+}
+
+// componentsDataSourceEntryModel maps one listed component.
+type componentsDataSourceEntryModel struct {
+	ID       types.String `tfsdk:"id"`
+	Name     types.String `tfsdk:"name"`
+	ImportID types.String `tfsdk:"import_id"`
+}
+
+// Metadata returns the data source type name.
+func (d *componentsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_components"
+}
+
+// Schema defines the schema for the data source.
+func (d *componentsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the components of a page, e.g. to import them all with import blocks.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "String Identifier of the page of the components.",
+				Computed:    true,
+			},
+			"page_id": schema.StringAttribute{
+				Description: "String Identifier of the page of the components. Defaults to the provider default_page_id.",
+				Optional:    true,
+			},
+			"components": schema.ListNestedAttribute{
+				Description: "Components of the page, groups excluded, ordered by name.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "String Identifier of the component.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the component.",
+							Computed:    true,
+						},
+						"import_id": schema.StringAttribute{
+							Description: "Identifier importing the component as an instatus_component resource.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *componentsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.provider = req.ProviderData.(*instatusProviderData)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *componentsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state componentsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pageID := state.PageID.ValueString()
+	if state.PageID.IsNull() {
+		pageID = d.provider.defaultPageID
+	}
+	if pageID == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("page_id"),
+			"Missing Page ID",
+			"The page_id attribute must be set on the data source, or default_page_id must be set on the provider.",
+		)
+		return
+	}
+
+	components, err := d.provider.Client(ctx).ListComponents(pageID)
+	resp.Diagnostics.Append(d.provider.quota.Diagnostics()...)
+	if err != nil {
+		addAPIError(
+			&resp.Diagnostics,
+			"Unable to Read Instatus Components",
+			"Could not read the components of Instatus page ID "+pageID,
+			err,
+		)
+		return
+	}
+
+	// Map response body to model
+	state.ID = types.StringValue(pageID)
+	state.Components = []componentsDataSourceEntryModel{}
+	for _, component := range components {
+		if component.IsParent != nil && *component.IsParent {
+			continue
+		}
+		state.Components = append(state.Components, componentsDataSourceEntryModel{
+			ID:       types.StringPointerValue(component.ID),
+			Name:     types.StringPointerValue(component.Name),
+			ImportID: types.StringValue(pageID + "/" + *component.ID),
+		})
+	}
+	sort.SliceStable(state.Components, func(i, j int) bool {
+		return state.Components[i].Name.ValueString() < state.Components[j].Name.ValueString()
+	})
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,432 @@
+package instatus
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	is "github.com/brunoscota/instatus-client-go"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &componentsResource{}
+	_ resource.ResourceWithConfigure   = &componentsResource{}
+	_ resource.ResourceWithImportState = &componentsResource{}
+	_ resource.ResourceWithModifyPlan  = &componentsResource{}
+
+	_ resource.ResourceWithUpgradeState = &componentsResource{}
+)
+
+// Configure adds the provider configured client to the resource.
+func (r *componentsResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.provider = req.ProviderData.(*instatusProviderData)
+	r.defaultPageID = r.provider.defaultPageID
+}
+
+// NewComponentsResource is a helper function to simplify the provider implementation.
+func NewComponentsResource() resource.Resource {
+	return &componentsResource{}
+}
+
+// componentsResource manages all the components of a page at once.
+type componentsResource struct {
+	provider      *instatusProviderData
+	defaultPageID string
+}
+
+// componentsResourceModel maps the resource schema data.
+type componentsResourceModel struct {
+	ID         types.String                    `tfsdk:"id"`
+	PageID     types.String                    `tfsdk:"page_id"`
+	Components map[string]componentsEntryModel `tfsdk:"components"`
+	MaxRetries types.Int64                     `tfsdk:"max_retries"`
+	Timeouts   timeouts.Value                  `tfsdk:"timeouts"`
+}
+
+// componentsEntryModel maps one component of the resource, keyed by name.
+type componentsEntryModel struct {
+	ID          types.String `tfsdk:"id"`
+	Description types.String `tfsdk:"description"`
+	ShowUptime  types.Bool   `tfsdk:"show_uptime"`
+	GroupName   types.String `tfsdk:"group_name"`
+}
+
+// Metadata returns the resource type name.
+func (r *componentsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_components"
+}
+
+// Schema defines the schema for the resource.
+func (r *componentsResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages all the components of a page, refreshing them with a single API call. " +
+			"Components of the page missing from the components map are left untouched.",
+		Version: 1,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "String Identifier of the page of the components.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"page_id": schema.StringAttribute{
+				Description: "String Identifier of the page of the components. Defaults to the provider default_page_id. Changing it forces new components to be created.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"components": schema.MapNestedAttribute{
+				Description: "Components of the page, keyed by name.",
+				Required:    true,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(componentNameValidators()...),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "String Identifier of the component.",
+							Computed:    true,
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
+						},
+						"description": schema.StringAttribute{
+							Description: "Description of the component.",
+							Optional:    true,
+							Validators:  componentDescriptionValidators(),
+						},
+						"show_uptime": schema.BoolAttribute{
+							Description: "Whether show uptime is enabled in the component. Defaults to true.",
+							Optional:    true,
+							Computed:    true,
+							Default:     booldefault.StaticBool(true),
+						},
+						"group_name": schema.StringAttribute{
+							Description: "Name of the group of the component. Groups missing from the page are created.",
+							Optional:    true,
+							Validators:  componentNameValidators(),
+						},
+					},
+				},
+			},
+			"max_retries": schema.Int64Attribute{
+				Description: "Maximum number of times the API requests of these components are retried, overriding the provider max_retries. Set to 0 to disable retries.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// UpgradeState upgrades states written by earlier versions of the resource.
+func (r *componentsResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	// Version 1 is the first versioned schema. Version 0 states have the same
+	// attributes, max_retries and the timeouts block aside, which are left
+	// null.
+	return map[int64]resource.StateUpgrader{
+		0: {
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				upgradeStateToSchema(ctx, schemaResp.Schema, nil, req, resp)
+			},
+		},
+	}
+}
+
+// ModifyPlan fills in the page_id from the provider configuration when omitted.
+func (r *componentsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planDefaultPageID(ctx, r.defaultPageID, req, resp)
+}
+
+// Create creates the components and sets the initial Terraform state.
+func (r *componentsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan componentsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withMaxRetries(ctx, plan.MaxRetries)
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	state := componentsResourceModel{
+		ID:         plan.PageID,
+		PageID:     plan.PageID,
+		Components: make(map[string]componentsEntryModel, len(plan.Components)),
+		MaxRetries: plan.MaxRetries,
+		Timeouts:   plan.Timeouts,
+	}
+	r.apply(ctx, state.PageID.ValueString(), nil, plan.Components, state.Components, &resp.Diagnostics)
+
+	// Set the state of the components created so far, even on error
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *componentsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state componentsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withMaxRetries(ctx, state.MaxRetries)
+
+	// List all the components of the page at once
+	components, err := r.provider.Client(ctx).ListComponents(state.PageID.ValueString())
+	resp.Diagnostics.Append(r.provider.quota.Diagnostics()...)
+	if err != nil && r.provider.tolerateReadErrors && isTransientError(err) {
+		resp.Diagnostics.AddWarning(
+			"Instatus Components Not Refreshed",
+			"Could not reach the Instatus API to refresh the components of page ID "+state.PageID.ValueString()+", the previous state is kept: "+err.Error(),
+		)
+		return
+	}
+	if err != nil {
+		addAPIError(
+			&resp.Diagnostics,
+			"Error Reading Instatus Components",
+			"Could not read the components of Instatus page ID "+state.PageID.ValueString(),
+			err,
+		)
+		return
+	}
+
+	byID := make(map[string]component, len(components))
+	for _, component := range components {
+		byID[*component.ID] = component
+	}
+
+	// An imported resource adopts all the components of the page
+	imported := state.Components == nil
+	if imported {
+		state.Components = make(map[string]componentsEntryModel)
+		for _, component := range components {
+			if component.Name == nil || (component.IsParent != nil && *component.IsParent) {
+				continue
+			}
+			state.Components[*component.Name] = componentsEntryModel{
+				ID:        types.StringPointerValue(component.ID),
+				GroupName: types.StringPointerValue(component.Group.Name),
+			}
+		}
+	}
+
+	// Overwrite items with refreshed state, dropping the deleted components
+	// and keying the renamed ones by their name on Instatus
+	refreshed := make(map[string]componentsEntryModel, len(state.Components))
+	for name, entry := range state.Components {
+		component, ok := byID[entry.ID.ValueString()]
+		if !ok {
+			continue
+		}
+
+		entry.Description = types.StringPointerValue(component.Description)
+		if component.ShowUptime != nil {
+			entry.ShowUptime = types.BoolValue(*component.ShowUptime)
+		}
+		entry.GroupName = keepUntrimmed(entry.GroupName, component.Group.Name)
+		if component.Name != nil && strings.TrimSpace(name) != *component.Name {
+			name = *component.Name
+		}
+		refreshed[name] = entry
+	}
+	state.Components = refreshed
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update creates, updates and deletes components to match the plan.
+func (r *componentsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan and state
+	var plan, state componentsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withMaxRetries(ctx, plan.MaxRetries)
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	applied := make(map[string]componentsEntryModel, len(plan.Components))
+	r.apply(ctx, state.PageID.ValueString(), state.Components, plan.Components, applied, &resp.Diagnostics)
+	state.Components = applied
+	state.MaxRetries = plan.MaxRetries
+	state.Timeouts = plan.Timeouts
+
+	// Set the state of the components applied so far, even on error
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the components and removes the Terraform state on success.
+func (r *componentsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state componentsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withMaxRetries(ctx, state.MaxRetries)
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	remaining := make(map[string]componentsEntryModel)
+	r.apply(ctx, state.PageID.ValueString(), state.Components, nil, remaining, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		// Keep the components that could not be deleted in state
+		state.Components = remaining
+		resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+	}
+}
+
+// ImportState imports all the components of a page, by page ID.
+func (r *componentsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("page_id"), req.ID)...)
+}
+
+// apply moves the components of a page from the prior entries to the planned
+// ones, in name order, recording each entry applied successfully in applied
+// along with the prior entries that could not be deleted.
+func (r *componentsResource) apply(ctx context.Context, pageID string, prior, planned, applied map[string]componentsEntryModel, diags *diag.Diagnostics) {
+	client := r.provider.Client(ctx)
+
+	for _, name := range sortedKeys(prior) {
+		entry := prior[name]
+		if _, ok := planned[name]; ok {
+			continue
+		}
+
+		err := client.DeleteComponent(pageID, entry.ID.ValueString())
+		r.provider.components.Invalidate(pageID)
+		if err != nil && !isNotFound(err) {
+			addAPIError(diags, "Error Deleting Instatus Component", "Could not delete component "+name, err)
+			applied[name] = entry
+		}
+	}
+
+	for _, name := range sortedKeys(planned) {
+		entry := planned[name]
+		priorEntry, exists := prior[name]
+		if exists && priorEntry.Description.Equal(entry.Description) && priorEntry.ShowUptime.Equal(entry.ShowUptime) && priorEntry.GroupName.Equal(entry.GroupName) {
+			applied[name] = priorEntry
+			continue
+		}
+
+		grouped := !entry.GroupName.IsNull()
+		trimmedName := strings.TrimSpace(name)
+		item := componentRequest{
+			Component: is.Component{
+				Name:        &trimmedName,
+				Description: entry.Description.ValueStringPointer(),
+				ShowUptime:  entry.ShowUptime.ValueBoolPointer(),
+				Grouped:     &grouped,
+				Group:       trimmedStringPointer(entry.GroupName),
+			},
+		}
+
+		// Point the component at the existing group of that name, as
+		// instatus_component does, creating the group when there is none.
+		groupPath := path.Root("components").AtMapKey(name).AtName("group_name")
+		diags.Append(resolveComponentGroup(ctx, r.provider, pageID, true, groupPath, &item)...)
+
+		var result *component
+		var err error
+		if exists {
+			result, err = client.UpdateComponent(pageID, priorEntry.ID.ValueString(), &item)
+		} else {
+			result, err = client.CreateComponent(pageID, &item)
+		}
+		// The API may have created the group, which the next components
+		// naming it must find.
+		r.provider.components.Invalidate(pageID)
+		if err != nil {
+			addAPIError(diags, "Error Applying Instatus Component", "Could not apply component "+trimmedName, err)
+			if exists {
+				applied[name] = priorEntry
+			}
+			continue
+		}
+
+		entry.ID = types.StringPointerValue(result.ID)
+		applied[name] = entry
+	}
+
+	diags.Append(r.provider.quota.Diagnostics()...)
+}
+
+// sortedKeys returns the keys of a map of components in order.
+func sortedKeys(entries map[string]componentsEntryModel) []string {
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
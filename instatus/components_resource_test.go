@@ -0,0 +1,134 @@
+package instatus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// applyComponents moves the components of the test page from prior to
+// planned, returning the entries applied.
+func applyComponents(t *testing.T, client *fakeClient, prior, planned map[string]componentsEntryModel) (map[string]componentsEntryModel, diag.Diagnostics) {
+	t.Helper()
+	r := &componentsResource{provider: newTestComponentResource(client).provider}
+
+	var diags diag.Diagnostics
+	applied := make(map[string]componentsEntryModel)
+	r.apply(context.Background(), testPageID, prior, planned, applied, &diags)
+
+	return applied, diags
+}
+
+// testComponentsEntry returns an entry of a new component in the named group.
+func testComponentsEntry(groupName string) componentsEntryModel {
+	return componentsEntryModel{
+		ID:          types.StringUnknown(),
+		Description: types.StringNull(),
+		ShowUptime:  types.BoolValue(true),
+		GroupName:   types.StringValue(groupName),
+	}
+}
+
+func TestComponentsApplyCreatesMissingGroupOnce(t *testing.T) {
+	client := newFakeClient()
+
+	applied, diags := applyComponents(t, client, nil, map[string]componentsEntryModel{
+		"Checkout": testComponentsEntry("Payments"),
+		"Refunds":  testComponentsEntry("Payments"),
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if len(client.groups) != 3 {
+		t.Errorf("expected a single Payments group to be created, got %v", client.groups)
+	}
+	checkout := client.components[applied["Checkout"].ID.ValueString()]
+	refunds := client.components[applied["Refunds"].ID.ValueString()]
+	if stringValue(checkout.Group.Id) != stringValue(refunds.Group.Id) {
+		t.Errorf("expected both components in the same group, got %q and %q", stringValue(checkout.Group.Id), stringValue(refunds.Group.Id))
+	}
+}
+
+func TestComponentsApplyTrimsNames(t *testing.T) {
+	client := newFakeClient()
+
+	applied, diags := applyComponents(t, client, nil, map[string]componentsEntryModel{
+		" Checkout ": testComponentsEntry("Backend"),
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got := stringValue(client.creates[0].Name); got != "Checkout" {
+		t.Errorf("expected the name Checkout to be sent, got %q", got)
+	}
+	if _, ok := applied[" Checkout "]; !ok {
+		t.Errorf("expected the entry to keep its configured key, got %v", applied)
+	}
+}
+
+// deadlineClient records the deadline of the context it is created with.
+type deadlineClient struct {
+	*fakeClient
+	deadline time.Time
+}
+
+func TestComponentsCreateAppliesTimeout(t *testing.T) {
+	ctx := context.Background()
+	client := &deadlineClient{fakeClient: newFakeClient()}
+	r := &componentsResource{provider: newTestComponentResource(client).provider}
+	r.provider.newClient = func(ctx context.Context) instatusClient {
+		client.deadline, _ = ctx.Deadline()
+		return client
+	}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	plan := componentsResourceModel{
+		ID:         types.StringValue(testPageID),
+		PageID:     types.StringValue(testPageID),
+		Components: map[string]componentsEntryModel{"Checkout": testComponentsEntry("Backend")},
+		MaxRetries: types.Int64Value(0),
+		Timeouts: timeouts.Value{Object: types.ObjectValueMust(
+			map[string]attr.Type{"create": types.StringType, "update": types.StringType, "delete": types.StringType},
+			map[string]attr.Value{"create": types.StringValue("1m"), "update": types.StringNull(), "delete": types.StringNull()},
+		)},
+	}
+	req := resource.CreateRequest{Plan: tfsdk.Plan{Schema: schemaResp.Schema}}
+	if diags := req.Plan.Set(ctx, plan); diags.HasError() {
+		t.Fatalf("setting plan: %v", diags)
+	}
+	resp := resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+	}
+
+	r.Create(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	if client.deadline.IsZero() || client.deadline.After(time.Now().Add(time.Minute)) {
+		t.Errorf("expected the requests to be bound by the 1m create timeout, got deadline %s", client.deadline)
+	}
+
+	var state componentsResourceModel
+	if diags := resp.State.Get(ctx, &state); diags.HasError() {
+		t.Fatalf("getting state: %v", diags)
+	}
+	if state.MaxRetries.ValueInt64() != 0 || state.MaxRetries.IsNull() {
+		t.Errorf("expected max_retries 0 in state, got %s", state.MaxRetries)
+	}
+	if state.Components["Checkout"].ID.IsUnknown() {
+		t.Error("expected the component ID to be set")
+	}
+}
@@ -0,0 +1,179 @@
+package instatus
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	is "github.com/brunoscota/instatus-client-go"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// apiError is returned for Instatus API responses with an error status code.
+type apiError struct {
+	StatusCode int
+	Method     string
+	Path       string
+	// Message is the error reported in the response body, if any.
+	Message string
+	// RequestID identifies the request for Instatus support, if any.
+	RequestID string
+}
+
+// requestIDHeaders lists the response headers that may carry the identifier
+// of an API request, in order of preference.
+var requestIDHeaders = []string{"X-Request-Id", "X-Amzn-Requestid", "X-Vercel-Id", "Cf-Ray"}
+
+// requestID returns the identifier of the request that produced resp.
+func requestID(resp *http.Response) string {
+	for _, header := range requestIDHeaders {
+		if id := resp.Header.Get(header); id != "" {
+			return id
+		}
+	}
+
+	return ""
+}
+
+// Error implements the error interface.
+func (e *apiError) Error() string {
+	msg := fmt.Sprintf("%s %s returned %d %s", e.Method, e.Path, e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if e.RequestID != "" {
+		msg += " (request ID: " + e.RequestID + ")"
+	}
+
+	return msg
+}
+
+// isNotFound reports whether err is an Instatus API 404 response.
+func isNotFound(err error) bool {
+	var apiErr *apiError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// isAuthError reports whether err is an Instatus API 401 or 403 response,
+// caused by the API key rather than by the request.
+func isAuthError(err error) bool {
+	var apiErr *apiError
+	return errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden)
+}
+
+// isTransientError reports whether err was caused by the Instatus API being
+// unreachable or failing, rather than by the request itself.
+func isTransientError(err error) bool {
+	var apiErr *apiError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	var circuitErr *circuitOpenError
+	var netErr net.Error
+	return errors.As(err, &circuitErr) || errors.As(err, &netErr)
+}
+
+// errorClient turns Instatus API error responses into *apiError values, so
+// resources can report them precisely instead of relying on the status code
+// formatting of the Instatus client.
+type errorClient struct {
+	client is.HTTPClient
+}
+
+// Do sends the request and converts error responses.
+func (c *errorClient) Do(req *http.Request) (*http.Response, error) {
+	resp, err := c.client.Do(req)
+	if err != nil || resp.StatusCode < http.StatusBadRequest {
+		return resp, err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+
+	apiErr := &apiError{
+		StatusCode: resp.StatusCode,
+		Method:     req.Method,
+		Path:       req.URL.Path,
+		Message:    parseErrorMessage(body),
+		RequestID:  requestID(resp),
+	}
+
+	tflog.Debug(req.Context(), "Instatus API returned an error", map[string]interface{}{
+		"method":      apiErr.Method,
+		"path":        apiErr.Path,
+		"status_code": apiErr.StatusCode,
+		"request_id":  apiErr.RequestID,
+	})
+
+	return nil, apiErr
+}
+
+// parseErrorMessage extracts the error message of an Instatus API response
+// body, falling back to the raw body when it is not JSON.
+func parseErrorMessage(body []byte) string {
+	var payload struct {
+		Message string `json:"message"`
+		Error   string `json:"error"`
+	}
+	if err := json.Unmarshal(body, &payload); err == nil {
+		if payload.Message != "" {
+			return payload.Message
+		}
+		return payload.Error
+	}
+
+	return strings.TrimSpace(string(body))
+}
+
+// addAPIError appends an error diagnostic for a failed Instatus API call,
+// explaining the most common failure causes. action describes what was
+// attempted, e.g. "Could not create component".
+func addAPIError(diags *diag.Diagnostics, summary string, action string, err error) {
+	var circuitErr *circuitOpenError
+	if errors.As(err, &circuitErr) {
+		diags.AddError(summary, action+": the Instatus API is failing consistently, so the request was not sent.\n\n"+
+			"Request "+circuitErr.Error()+". "+
+			"Rerun the operation once the Instatus API has recovered.")
+		return
+	}
+
+	var apiErr *apiError
+	if !errors.As(err, &apiErr) {
+		diags.AddError(summary, action+", unexpected error: "+err.Error())
+		return
+	}
+
+	var hint string
+	switch {
+	case apiErr.StatusCode == http.StatusUnauthorized:
+		hint = "The Instatus API key is invalid or has expired. Check the api_key provider attribute or the INSTATUS_APIKEY environment variable."
+	case apiErr.StatusCode == http.StatusForbidden:
+		hint = "The Instatus API key lacks access to " + apiErr.Path + ". Ensure the key belongs to a member of the page's team with write access."
+	case apiErr.StatusCode == http.StatusNotFound:
+		hint = "The requested object does not exist on Instatus. Check the page_id and id values."
+	case apiErr.StatusCode == http.StatusConflict:
+		hint = "The request conflicts with the current state of the object on Instatus, e.g. a duplicate name. Refresh the state and retry."
+	case apiErr.StatusCode == http.StatusUnprocessableEntity:
+		hint = "Instatus rejected the request as invalid. Check the attribute values against the Instatus API documentation."
+	case apiErr.StatusCode == http.StatusTooManyRequests:
+		hint = "The Instatus API rate limit was still exceeded after retrying. Lower requests_per_second or raise max_retries in the provider configuration."
+	case apiErr.StatusCode >= http.StatusInternalServerError:
+		hint = "The Instatus API is failing. Retry the operation later."
+	}
+
+	detail := action + ": " + apiErr.Error()
+	if hint != "" {
+		detail += "\n\n" + hint
+	}
+	if apiErr.RequestID != "" {
+		detail += "\n\nReference request ID " + apiErr.RequestID + " when contacting Instatus support."
+	}
+
+	diags.AddError(summary, detail)
+}
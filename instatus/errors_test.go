@@ -0,0 +1,159 @@
+package instatus
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestErrorClientMapsErrorResponses(t *testing.T) {
+	tests := map[string]struct {
+		status      int
+		header      http.Header
+		body        string
+		wantMessage string
+		wantID      string
+	}{
+		"JSON message": {
+			status:      http.StatusUnprocessableEntity,
+			header:      http.Header{"X-Request-Id": []string{"req-1"}},
+			body:        `{"message":"name is too long"}`,
+			wantMessage: "name is too long",
+			wantID:      "req-1",
+		},
+		"JSON error": {
+			status:      http.StatusForbidden,
+			body:        `{"error":"forbidden"}`,
+			wantMessage: "forbidden",
+		},
+		"plain text": {
+			status:      http.StatusBadGateway,
+			header:      http.Header{"Cf-Ray": []string{"ray-1"}},
+			body:        "Bad Gateway\n",
+			wantMessage: "Bad Gateway",
+			wantID:      "ray-1",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &handlerClient{handle: func(req *http.Request) (*http.Response, error) {
+				return newTestResponse(req, test.status, test.header, test.body), nil
+			}}
+
+			resp, err := (&errorClient{client: client}).Do(newTestRequest(t, http.MethodGet))
+			if resp != nil {
+				t.Errorf("expected no response, got status %d", resp.StatusCode)
+			}
+
+			var apiErr *apiError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("expected an API error, got %v", err)
+			}
+			if apiErr.StatusCode != test.status || apiErr.Method != http.MethodGet || apiErr.Path != "/v1/"+testPageID+"/components" {
+				t.Errorf("expected GET /v1/%s/components returning %d, got %s", testPageID, test.status, apiErr)
+			}
+			if apiErr.Message != test.wantMessage || apiErr.RequestID != test.wantID {
+				t.Errorf("expected message %q and request ID %q, got %q and %q", test.wantMessage, test.wantID, apiErr.Message, apiErr.RequestID)
+			}
+		})
+	}
+}
+
+func TestErrorClientPassesSuccesses(t *testing.T) {
+	client := &handlerClient{handle: statusSequence(nil, http.StatusNotModified)}
+
+	resp, err := (&errorClient{client: client}).Do(newTestRequest(t, http.MethodGet))
+	if err != nil || resp.StatusCode != http.StatusNotModified {
+		t.Errorf("expected the response to be returned, got %v, %v", resp, err)
+	}
+}
+
+func TestAddAPIError(t *testing.T) {
+	tests := map[string]struct {
+		err        error
+		wantDetail string
+	}{
+		"unauthorized": {
+			err:        &apiError{StatusCode: 401, Method: "GET", Path: "/v1/user"},
+			wantDetail: "Check the api_key provider attribute",
+		},
+		"forbidden": {
+			err:        &apiError{StatusCode: 403, Method: "PUT", Path: "/v1/page/components/api"},
+			wantDetail: "lacks access to /v1/page/components/api",
+		},
+		"not found": {
+			err:        &apiError{StatusCode: 404, Method: "GET", Path: "/v1/page/components/api"},
+			wantDetail: "Check the page_id and id values",
+		},
+		"conflict": {
+			err:        &apiError{StatusCode: 409, Method: "POST", Path: "/v1/page/components"},
+			wantDetail: "conflicts with the current state",
+		},
+		"invalid": {
+			err:        &apiError{StatusCode: 422, Method: "POST", Path: "/v1/page/components", Message: "name is too long"},
+			wantDetail: "returned 422 Unprocessable Entity: name is too long",
+		},
+		"rate limited": {
+			err:        &apiError{StatusCode: 429, Method: "GET", Path: "/v1/page/components"},
+			wantDetail: "Lower requests_per_second or raise max_retries",
+		},
+		"server error with request ID": {
+			err:        &apiError{StatusCode: 500, Method: "GET", Path: "/v1/page/components", RequestID: "req-1"},
+			wantDetail: "Reference request ID req-1",
+		},
+		"circuit open": {
+			err:        &circuitOpenError{failures: 5, lastError: "502 Bad Gateway", retryAt: time.Now()},
+			wantDetail: "so the request was not sent",
+		},
+		"unexpected": {
+			err:        errors.New("connection reset"),
+			wantDetail: "Could not read component, unexpected error: connection reset",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			addAPIError(&diags, "Error Reading Instatus Component", "Could not read component", test.err)
+
+			if len(diags) != 1 || diags.ErrorsCount() != 1 {
+				t.Fatalf("expected a single error, got %v", diags)
+			}
+			if summary := diags[0].Summary(); summary != "Error Reading Instatus Component" {
+				t.Errorf("expected the given summary, got %q", summary)
+			}
+			if detail := diags[0].Detail(); !strings.Contains(detail, test.wantDetail) {
+				t.Errorf("expected the detail to contain %q, got %q", test.wantDetail, detail)
+			}
+		})
+	}
+}
+
+func TestIsTransientError(t *testing.T) {
+	tests := map[string]struct {
+		err  error
+		want bool
+	}{
+		"server error":  {err: &apiError{StatusCode: 502}, want: true},
+		"rate limited":  {err: &apiError{StatusCode: 429}, want: true},
+		"circuit open":  {err: &circuitOpenError{}, want: true},
+		"network error": {err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}, want: true},
+		"not found":     {err: &apiError{StatusCode: 404}},
+		"unauthorized":  {err: &apiError{StatusCode: 401}},
+		"other error":   {err: errors.New("invalid character")},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := isTransientError(test.err); got != test.want {
+				t.Errorf("expected %t, got %t", test.want, got)
+			}
+		})
+	}
+}
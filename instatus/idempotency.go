@@ -0,0 +1,45 @@
+package instatus
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	is "github.com/brunoscota/instatus-client-go"
+)
+
+// idempotencyKeyHeader is the header carrying the idempotency key of a create
+// request.
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// idempotencyClient tags create requests with an idempotency key that stays
+// the same across retries, so an API honoring it doesn't create a duplicate
+// object when a retried request had in fact already succeeded.
+type idempotencyClient struct {
+	client is.HTTPClient
+}
+
+// Do sets an idempotency key on POST requests that don't carry one yet.
+func (c *idempotencyClient) Do(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodPost && req.Header.Get(idempotencyKeyHeader) == "" {
+		key, err := newIdempotencyKey()
+		if err != nil {
+			return nil, err
+		}
+
+		req = req.Clone(req.Context())
+		req.Header.Set(idempotencyKeyHeader, key)
+	}
+
+	return c.client.Do(req)
+}
+
+// newIdempotencyKey returns a random 128-bit key encoded as hexadecimal.
+func newIdempotencyKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
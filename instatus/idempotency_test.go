@@ -0,0 +1,72 @@
+package instatus
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestIdempotencyKeyKeptAcrossRetries(t *testing.T) {
+	client := &handlerClient{handle: statusSequence(nil, http.StatusServiceUnavailable, http.StatusCreated)}
+	idempotency := &idempotencyClient{client: newTestRetryClient(client, 1)}
+
+	if _, err := idempotency.Do(newTestRequest(t, http.MethodPost)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(client.requests) != 2 {
+		t.Fatalf("expected the create to be retried, got %d requests", len(client.requests))
+	}
+
+	key := client.requests[0].Header.Get(idempotencyKeyHeader)
+	if key == "" {
+		t.Fatal("expected the create to carry an idempotency key")
+	}
+	if retried := client.requests[1].Header.Get(idempotencyKeyHeader); retried != key {
+		t.Errorf("expected the retry to reuse key %s, got %s", key, retried)
+	}
+}
+
+func TestIdempotencyKeyOnlyOnCreates(t *testing.T) {
+	tests := map[string]struct {
+		method  string
+		key     string
+		wantKey bool
+	}{
+		"create": {
+			method:  http.MethodPost,
+			wantKey: true,
+		},
+		"create with a key": {
+			method:  http.MethodPost,
+			key:     "caller-key",
+			wantKey: true,
+		},
+		"read": {
+			method: http.MethodGet,
+		},
+		"update": {
+			method: http.MethodPut,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &handlerClient{handle: statusSequence(nil, http.StatusOK)}
+
+			req := newTestRequest(t, test.method)
+			if test.key != "" {
+				req.Header.Set(idempotencyKeyHeader, test.key)
+			}
+			if _, err := (&idempotencyClient{client: client}).Do(req); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			key := client.requests[0].Header.Get(idempotencyKeyHeader)
+			if (key != "") != test.wantKey {
+				t.Errorf("expected an idempotency key %t, got %q", test.wantKey, key)
+			}
+			if test.key != "" && key != test.key {
+				t.Errorf("expected the key of the caller to be kept, got %q", key)
+			}
+		})
+	}
+}
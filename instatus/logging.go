@@ -0,0 +1,40 @@
+package instatus
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// loggingTransport logs the metadata of every Instatus API request through
+// tflog. Request and response bodies are never logged and the API key is
+// masked from all log fields.
+type loggingTransport struct {
+	transport http.RoundTripper
+}
+
+// RoundTrip sends the request and logs its outcome at DEBUG level.
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.transport.RoundTrip(req)
+
+	fields := map[string]interface{}{
+		"method":      req.Method,
+		"path":        req.URL.Path,
+		"duration_ms": time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.Debug(req.Context(), "Instatus API request failed", fields)
+		return resp, err
+	}
+
+	fields["status_code"] = resp.StatusCode
+	if id := requestID(resp); id != "" {
+		fields["request_id"] = id
+	}
+	tflog.Debug(req.Context(), "Instatus API request", fields)
+
+	return resp, nil
+}
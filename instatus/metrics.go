@@ -0,0 +1,161 @@
+package instatus
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	is "github.com/brunoscota/instatus-client-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// operationMetrics summarizes the API calls of one operation, e.g. all GET
+// requests for components.
+type operationMetrics struct {
+	Calls        int   `json:"calls"`
+	Errors       int   `json:"errors"`
+	TotalMillis  int64 `json:"total_ms"`
+	AvgMillis    int64 `json:"avg_ms"`
+	MaxMillis    int64 `json:"max_ms"`
+	totalLatency time.Duration
+	maxLatency   time.Duration
+}
+
+// apiMetrics aggregates the count and latency of Instatus API calls, retries
+// included, so the share of a run spent waiting on the API can be measured.
+// The summary is only reported once the run ends, by Flush.
+type apiMetrics struct {
+	// logCtx carries the provider logger the summary is logged with.
+	logCtx context.Context
+	// path is the JSON file the summary is written to, if set.
+	path string
+
+	mu         sync.Mutex
+	operations map[string]*operationMetrics
+}
+
+// record adds an API call to the summary.
+func (m *apiMetrics) record(key string, latency time.Duration, failed bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.operations == nil {
+		m.operations = make(map[string]*operationMetrics)
+	}
+	op, ok := m.operations[key]
+	if !ok {
+		op = &operationMetrics{}
+		m.operations[key] = op
+	}
+
+	op.Calls++
+	if failed {
+		op.Errors++
+	}
+	op.totalLatency += latency
+	if latency > op.maxLatency {
+		op.maxLatency = latency
+	}
+	op.TotalMillis = op.totalLatency.Milliseconds()
+	op.AvgMillis = (op.totalLatency / time.Duration(op.Calls)).Milliseconds()
+	op.MaxMillis = op.maxLatency.Milliseconds()
+}
+
+// Flush logs the summary at INFO level and writes it to the metrics file, if
+// set.
+func (m *apiMetrics) Flush() error {
+	m.mu.Lock()
+	operations := make(map[string]operationMetrics, len(m.operations))
+	for key, op := range m.operations {
+		operations[key] = *op
+	}
+	m.mu.Unlock()
+
+	keys := make([]string, 0, len(operations))
+	for key := range operations {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		op := operations[key]
+		tflog.Info(m.logCtx, "Instatus API calls", map[string]interface{}{
+			"operation": key,
+			"calls":     op.Calls,
+			"errors":    op.Errors,
+			"total_ms":  op.TotalMillis,
+			"avg_ms":    op.AvgMillis,
+			"max_ms":    op.MaxMillis,
+		})
+	}
+
+	if m.path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(operations, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(m.path, data)
+}
+
+// writeFileAtomic replaces the content of a file, so readers never see it
+// partially written.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// metricsClient records the outcome of every Instatus API call.
+type metricsClient struct {
+	client  is.HTTPClient
+	metrics *apiMetrics
+}
+
+// Do sends the request and records its outcome.
+func (c *metricsClient) Do(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := c.client.Do(req)
+	c.metrics.record(req.Method+" "+apiResourceName(req.URL.Path), time.Since(start), err != nil)
+
+	return resp, err
+}
+
+// apiResourceName returns the kind of object an API path refers to, e.g.
+// "components" for /v1/{pageId}/components/{componentId}.
+func apiResourceName(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) > 0 && len(segments[0]) > 1 && segments[0][0] == 'v' && strings.Trim(segments[0][1:], "0123456789") == "" {
+		segments = segments[1:]
+	}
+
+	switch len(segments) {
+	case 0:
+		return ""
+	case 1:
+		return segments[0]
+	default:
+		return segments[1]
+	}
+}
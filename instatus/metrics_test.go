@@ -0,0 +1,58 @@
+package instatus
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMetricsWrittenOnClose(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.json")
+	metrics := &apiMetrics{logCtx: context.Background(), path: path}
+	client := &metricsClient{client: &handlerClient{handle: statusSequence(nil, http.StatusOK)}, metrics: metrics}
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.Do(newTestRequest(t, http.MethodGet)); err != nil {
+			t.Fatalf("request %d: unexpected error: %v", i, err)
+		}
+	}
+
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected the metrics file not to be written before the provider stops, got %v", err)
+	}
+
+	provider := &instatusProvider{metrics: []*apiMetrics{metrics}}
+	if err := provider.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading metrics file: %v", err)
+	}
+	var operations map[string]operationMetrics
+	if err := json.Unmarshal(data, &operations); err != nil {
+		t.Fatalf("decoding metrics file: %v", err)
+	}
+	if got := operations["GET components"].Calls; got != 3 {
+		t.Errorf("expected 3 component reads, got %d in %s", got, data)
+	}
+}
+
+func TestAPIResourceName(t *testing.T) {
+	tests := map[string]string{
+		"/v1/pages":                     "pages",
+		"/v2/page/components":           "components",
+		"/v1/page/components/component": "components",
+		"/v1/user":                      "user",
+	}
+
+	for path, want := range tests {
+		if got := apiResourceName(path); got != want {
+			t.Errorf("apiResourceName(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package instatus
+
+import (
+	"fmt"
+	"sync"
+)
+
+// pageURLs resolves the public URL of status pages, listing the pages once
+// for all of them. Failed lists are not kept, so the next call retries.
+type pageURLs struct {
+	mu   sync.Mutex
+	urls map[string]string
+}
+
+// Get returns the public URL of a page, preferring its custom domain over
+// its instatus.com subdomain.
+func (p *pageURLs) Get(client instatusClient, pageID string) (string, error) {
+	p.mu.Lock()
+	url, ok := p.urls[pageID]
+	p.mu.Unlock()
+	if ok {
+		return url, nil
+	}
+
+	pages, err := client.ListPages()
+	if err != nil {
+		return "", err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.urls == nil {
+		p.urls = make(map[string]string, len(pages))
+	}
+	for _, page := range pages {
+		switch {
+		case page.ID == nil:
+		case page.CustomDomain != nil && *page.CustomDomain != "":
+			p.urls[*page.ID] = "https://" + *page.CustomDomain
+		case page.Subdomain != nil && *page.Subdomain != "":
+			p.urls[*page.ID] = "https://" + *page.Subdomain + ".instatus.com"
+		}
+	}
+
+	url, ok = p.urls[pageID]
+	if !ok {
+		return "", fmt.Errorf("page %s not found", pageID)
+	}
+
+	return url, nil
+}
@@ -0,0 +1,90 @@
+package instatus
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// pagesClient lists the given pages, failing while err is set.
+type pagesClient struct {
+	instatusClient
+
+	pages []page
+	err   error
+	calls int
+}
+
+func (c *pagesClient) ListPages() ([]page, error) {
+	c.calls++
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	return c.pages, nil
+}
+
+func TestPageURLsGet(t *testing.T) {
+	client := &pagesClient{pages: []page{
+		{ID: stringPointer("custom"), Subdomain: stringPointer("acme"), CustomDomain: stringPointer("status.acme.com")},
+		{ID: stringPointer("hosted"), Subdomain: stringPointer("example")},
+	}}
+	urls := &pageURLs{}
+
+	for pageID, want := range map[string]string{
+		"custom": "https://status.acme.com",
+		"hosted": "https://example.instatus.com",
+	} {
+		got, err := urls.Get(client, pageID)
+		if err != nil {
+			t.Fatalf("unexpected error for page %s: %v", pageID, err)
+		}
+		if got != want {
+			t.Errorf("expected URL %s for page %s, got %s", want, pageID, got)
+		}
+	}
+	if client.calls != 1 {
+		t.Errorf("expected the pages to be listed once, got %d calls", client.calls)
+	}
+
+	if _, err := urls.Get(client, "unknown"); err == nil {
+		t.Error("expected an error for an unknown page")
+	}
+}
+
+func TestPageURLsGetRetriesAfterError(t *testing.T) {
+	client := &pagesClient{
+		pages: []page{{ID: stringPointer("hosted"), Subdomain: stringPointer("example")}},
+		err:   errors.New("connection refused"),
+	}
+	urls := &pageURLs{}
+
+	if _, err := urls.Get(client, "hosted"); err == nil {
+		t.Fatal("expected the list error")
+	}
+
+	client.err = nil
+	got, err := urls.Get(client, "hosted")
+	if err != nil {
+		t.Fatalf("expected the pages to be listed again, got %v", err)
+	}
+	if got != "https://example.instatus.com" {
+		t.Errorf("expected URL https://example.instatus.com, got %s", got)
+	}
+}
+
+func TestComponentURL(t *testing.T) {
+	r := newTestComponentResource(newFakeClient())
+
+	got := r.componentURL(context.Background(), testPageID, "api", types.StringUnknown())
+	if want := types.StringValue("https://example.instatus.com/#api"); !got.Equal(want) {
+		t.Errorf("expected URL %s, got %s", want, got)
+	}
+
+	got = r.componentURL(context.Background(), "unknown", "api", types.StringUnknown())
+	if !got.IsNull() {
+		t.Errorf("expected a null URL for an unknown page, got %s", got)
+	}
+}
@@ -2,37 +2,124 @@ package instatus
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
 	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
-	is "github.com/brunoscota/instatus-client-go"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure the implementation satisfies the expected interfaces
 var (
-	_ provider.Provider = &instatusProvider{}
+	_ provider.Provider                   = &instatusProvider{}
+	_ provider.ProviderWithValidateConfig = &instatusProvider{}
+	_ provider.ProviderWithListResources  = &instatusProvider{}
+	_ io.Closer                           = &instatusProvider{}
 )
 
 // New is a helper function to simplify provider server and testing implementation.
-func New() provider.Provider {
-	return &instatusProvider{}
+func New(version string) func() provider.Provider {
+	return func() provider.Provider {
+		return &instatusProvider{
+			version: version,
+		}
+	}
 }
 
 // instatusProvider is the provider implementation.
-type instatusProvider struct{}
+type instatusProvider struct {
+	// version is the provider release, "dev" for local builds.
+	version string
+
+	mu sync.Mutex
+	// metrics are reported by Close, when the provider stops.
+	metrics []*apiMetrics
+}
 
 type instatusProviderModel struct {
-	ApiKey types.String `tfsdk:"api_key"`
+	ApiKey                  types.String  `tfsdk:"api_key"`
+	ApiKeys                 types.List    `tfsdk:"api_keys"`
+	ApiEndpoint             types.String  `tfsdk:"api_endpoint"`
+	ConnectTimeout          types.String  `tfsdk:"connect_timeout"`
+	RequestTimeout          types.String  `tfsdk:"request_timeout"`
+	MaxRetries              types.Int64   `tfsdk:"max_retries"`
+	RetryMinWait            types.String  `tfsdk:"retry_min_wait"`
+	RetryMaxWait            types.String  `tfsdk:"retry_max_wait"`
+	RequestsPerSecond       types.Float64 `tfsdk:"requests_per_second"`
+	ProxyURL                types.String  `tfsdk:"proxy_url"`
+	CACertFile              types.String  `tfsdk:"ca_cert_file"`
+	InsecureSkipVerify      types.Bool    `tfsdk:"insecure_skip_verify"`
+	UserAgentSuffix         types.String  `tfsdk:"user_agent_suffix"`
+	DefaultPageID           types.String  `tfsdk:"default_page_id"`
+	LogRequests             types.Bool    `tfsdk:"log_requests"`
+	ReadOnly                types.Bool    `tfsdk:"read_only"`
+	Parallelism             types.Int64   `tfsdk:"parallelism"`
+	CircuitBreakerThreshold types.Int64   `tfsdk:"circuit_breaker_threshold"`
+	CacheTTL                types.String  `tfsdk:"cache_ttl"`
+	MetricsFile             types.String  `tfsdk:"metrics_file"`
+	TolerateReadErrors      types.Bool    `tfsdk:"tolerate_read_errors"`
+}
+
+// instatusProviderData is made available to data sources and resources by
+// Configure.
+type instatusProviderData struct {
+	newClient func(ctx context.Context) instatusClient
+	// defaultPageID is used by resources whose page_id is omitted.
+	defaultPageID string
+	// components batches component reads per page.
+	components *componentBatch
+	// pageURLs resolves the public URL of status pages.
+	pageURLs *pageURLs
+	// quota reports when the API rate limit is nearly exhausted.
+	quota *quotaMonitor
+	// tolerateReadErrors keeps the prior state of resources that could not
+	// be refreshed because the API was unreachable.
+	tolerateReadErrors bool
+}
+
+// Client returns an Instatus client whose requests are bound to ctx.
+func (d *instatusProviderData) Client(ctx context.Context) instatusClient {
+	return d.newClient(ctx)
+}
+
+// Close reports the API call metrics gathered during the run. It is called
+// once the provider server stops.
+func (p *instatusProvider) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var errs []error
+	for _, metrics := range p.metrics {
+		if err := metrics.Flush(); err != nil {
+			errs = append(errs, fmt.Errorf("writing metrics file %s: %w", metrics.path, err))
+		}
+	}
+	p.metrics = nil
+
+	return errors.Join(errs...)
 }
 
 // Metadata returns the provider type name.
 func (p *instatusProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
 	resp.TypeName = "instatus"
+	resp.Version = p.version
 }
 
 // Schema defines the provider-level schema for configuration data.
@@ -45,10 +132,170 @@ func (p *instatusProvider) Schema(_ context.Context, _ provider.SchemaRequest, r
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"api_keys": schema.ListAttribute{
+				Description: "API Keys for Instatus API, tried in order: when the API rejects a key the provider fails over to the next one, which keeps runs working during key rotations. Conflicts with api_key.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+				Validators:  []validator.List{listvalidator.SizeAtLeast(1)},
+			},
+			"api_endpoint": schema.StringAttribute{
+				Description: "Base URL of the Instatus API, useful to target a proxy or a test double. Defaults to " + defaultAPIEndpoint + ". May also be provided via INSTATUS_API_ENDPOINT environment variable.",
+				Optional:    true,
+			},
+			"cache_ttl": schema.StringAttribute{
+				Description: "How long responses to Instatus API reads are cached and reused, as a duration string (e.g. \"30s\"). " +
+					"Any create, update or delete clears the cache. When not set, every read is sent to the API, conditionally when a previous response carried an ETag or Last-Modified header.",
+				Optional: true,
+			},
+			"circuit_breaker_threshold": schema.Int64Attribute{
+				Description: fmt.Sprintf("Number of consecutive failed Instatus API requests after which the remaining requests fail immediately for %s, "+
+					"instead of each one waiting for its retries. Requests count as failed when they can't reach the API or get a server error once retried; "+
+					"rate-limited requests don't count. Set to 0 to disable. Defaults to %d.", circuitBreakerCooldown, defaultCircuitBreakerThreshold),
+				Optional:   true,
+				Validators: []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"connect_timeout": schema.StringAttribute{
+				Description: "Maximum time to wait for a connection to the Instatus API, as a duration string (e.g. \"10s\"). Defaults to \"" + defaultConnectTimeout.String() + "\".",
+				Optional:    true,
+			},
+			"request_timeout": schema.StringAttribute{
+				Description: "Maximum time to wait for a single Instatus API request to complete, as a duration string (e.g. \"1m\"). Defaults to \"" + defaultRequestTimeout.String() + "\".",
+				Optional:    true,
+			},
+			"max_retries": schema.Int64Attribute{
+				Description: fmt.Sprintf("Maximum number of times a rate-limited or failed Instatus API request is retried. Defaults to %d.", defaultMaxRetries),
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"retry_min_wait": schema.StringAttribute{
+				Description: "Time to wait before the first retry, doubled on every following attempt, as a duration string. Defaults to \"" + defaultRetryMinWait.String() + "\".",
+				Optional:    true,
+			},
+			"retry_max_wait": schema.StringAttribute{
+				Description: "Maximum time to wait between two retries, as a duration string. Defaults to \"" + defaultRetryMaxWait.String() + "\".",
+				Optional:    true,
+			},
+			"requests_per_second": schema.Float64Attribute{
+				Description: "Maximum number of Instatus API requests sent per second, shared by all resources. Unlimited when not set.",
+				Optional:    true,
+				Validators:  []validator.Float64{float64validator.AtLeast(0)},
+			},
+			"proxy_url": schema.StringAttribute{
+				Description: "URL of the proxy used to reach the Instatus API (http, https or socks5). " +
+					"When not set, the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are honored.",
+				Optional: true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Description: "Path to a PEM encoded CA bundle trusted in addition to the system certificates, e.g. for TLS-intercepting proxies.",
+				Optional:    true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Description: "Whether to skip verification of the Instatus API TLS certificate. Only use this against test endpoints.",
+				Optional:    true,
+			},
+			"tolerate_read_errors": schema.BoolAttribute{
+				Description: "Whether resources that cannot be refreshed because the Instatus API is unreachable or failing keep their previous state with a warning, " +
+					"instead of failing the run. Lets transient Instatus outages not block unrelated changes.",
+				Optional: true,
+			},
+			"user_agent_suffix": schema.StringAttribute{
+				Description: "Text appended to the User-Agent header sent with every Instatus API request.",
+				Optional:    true,
+			},
+			"default_page_id": schema.StringAttribute{
+				Description: "String Identifier of the page used by resources whose page_id is not set.",
+				Optional:    true,
+			},
+			"log_requests": schema.BoolAttribute{
+				Description: "Whether to log the method, path, status and duration of every Instatus API request at DEBUG level (see TF_LOG). " +
+					"The API key is redacted.",
+				Optional: true,
+			},
+			"metrics_file": schema.StringAttribute{
+				Description: "Path of a JSON file receiving the number, errors and latencies of Instatus API calls per method and object kind, " +
+					"to measure how much of a run is spent waiting on the API. The file is written, and the summary logged at INFO level, when the provider stops.",
+				Optional: true,
+			},
+			"parallelism": schema.Int64Attribute{
+				Description: "Maximum number of Instatus API requests in flight at once, independently of Terraform's -parallelism. Unlimited when not set.",
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"read_only": schema.BoolAttribute{
+				Description: "Whether the provider only performs read requests. Any create, update or delete fails with an error, " +
+					"which allows plans and applies to be audited safely against production pages.",
+				Optional: true,
+			},
 		},
 	}
 }
 
+// ValidateConfig checks that exactly one credential source is set and that
+// the API keys are not blank.
+func (p *instatusProvider) ValidateConfig(ctx context.Context, req provider.ValidateConfigRequest, resp *provider.ValidateConfigResponse) {
+	var apiKey types.String
+	var apiKeyList types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("api_key"), &apiKey)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("api_keys"), &apiKeyList)...)
+	if resp.Diagnostics.HasError() || apiKey.IsUnknown() || apiKeyList.IsUnknown() {
+		return
+	}
+
+	var apiKeys []types.String
+	resp.Diagnostics.Append(apiKeyList.ElementsAs(ctx, &apiKeys, false)...)
+	if resp.Diagnostics.HasError() || slices.ContainsFunc(apiKeys, types.String.IsUnknown) {
+		return
+	}
+
+	envAPIKey, envSet := os.LookupEnv("INSTATUS_APIKEY")
+
+	sources := 0
+	for _, set := range []bool{!apiKey.IsNull(), !apiKeyList.IsNull(), envSet} {
+		if set {
+			sources++
+		}
+	}
+
+	switch {
+	case sources > 1:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_key"),
+			"Conflicting Instatus API Keys",
+			"More than one of the api_key attribute, the api_keys attribute and the INSTATUS_APIKEY environment variable are set. "+
+				"Set exactly one of them so it is clear which key the provider uses.",
+		)
+	case sources == 0:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_key"),
+			"Missing Instatus API Key",
+			"Set the api_key or api_keys attribute in the provider configuration, or the INSTATUS_APIKEY environment variable.",
+		)
+	case !apiKey.IsNull() && strings.TrimSpace(apiKey.ValueString()) == "":
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_key"),
+			"Empty Instatus API Key",
+			"The api_key attribute must not be empty or only contain whitespace.",
+		)
+	case envSet && strings.TrimSpace(envAPIKey) == "":
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_key"),
+			"Empty Instatus API Key",
+			"The INSTATUS_APIKEY environment variable must not be empty or only contain whitespace.",
+		)
+	}
+
+	for i, key := range apiKeys {
+		if strings.TrimSpace(key.ValueString()) == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("api_keys").AtListIndex(i),
+				"Empty Instatus API Key",
+				"The api_keys elements must not be null, empty or only contain whitespace.",
+			)
+		}
+	}
+}
+
 // Configure prepares a Instatus API client for data sources and resources.
 func (p *instatusProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	// Retrieve provider data from configuration
@@ -71,6 +318,24 @@ func (p *instatusProvider) Configure(ctx context.Context, req provider.Configure
 		)
 	}
 
+	if config.ApiKeys.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_keys"),
+			"Unknown Instatus API Keys",
+			"The provider cannot create the Instatus API client as there is an unknown configuration value for the Instatus API Keys. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the INSTATUS_APIKEY environment variable.",
+		)
+	}
+
+	if config.ApiEndpoint.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_endpoint"),
+			"Unknown Instatus API Endpoint",
+			"The provider cannot create the Instatus API client as there is an unknown configuration value for the Instatus API Endpoint. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the INSTATUS_API_ENDPOINT environment variable.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -79,40 +344,282 @@ func (p *instatusProvider) Configure(ctx context.Context, req provider.Configure
 	// with Terraform configuration value if set.
 
 	apiKey := os.Getenv("INSTATUS_APIKEY")
+	apiEndpoint := os.Getenv("INSTATUS_API_ENDPOINT")
 
 	if !config.ApiKey.IsNull() {
 		apiKey = config.ApiKey.ValueString()
 	}
 
+	apiKeys := []string{apiKey}
+	if !config.ApiKeys.IsNull() {
+		apiKeys = nil
+		resp.Diagnostics.Append(config.ApiKeys.ElementsAs(ctx, &apiKeys, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if !config.ApiEndpoint.IsNull() {
+		apiEndpoint = config.ApiEndpoint.ValueString()
+	}
+
+	if apiEndpoint == "" {
+		apiEndpoint = defaultAPIEndpoint
+	}
+
 	// If any of the expected configurations are missing, return
 	// errors with provider-specific guidance.
 
-	if apiKey == "" {
+	if slices.ContainsFunc(apiKeys, func(key string) bool { return strings.TrimSpace(key) == "" }) {
 		resp.Diagnostics.AddAttributeError(
-			path.Root("apiKey"),
+			path.Root("api_key"),
 			"Missing Instatus API Key",
 			"The provider cannot create the Instatus API client as there is a missing or empty value for the Instatus API Key. "+
-				"Set the host value in the configuration or use the HASHICUPS_APIKEY environment variable. "+
+				"Set the api_key or api_keys value in the configuration or use the INSTATUS_APIKEY environment variable. "+
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
 
+	connectTimeout := parseDurationAttribute(config.ConnectTimeout, path.Root("connect_timeout"), defaultConnectTimeout, &resp.Diagnostics)
+	requestTimeout := parseDurationAttribute(config.RequestTimeout, path.Root("request_timeout"), defaultRequestTimeout, &resp.Diagnostics)
+
+	retryMinWait := parseDurationAttribute(config.RetryMinWait, path.Root("retry_min_wait"), defaultRetryMinWait, &resp.Diagnostics)
+	retryMaxWait := parseDurationAttribute(config.RetryMaxWait, path.Root("retry_max_wait"), defaultRetryMaxWait, &resp.Diagnostics)
+
+	if retryMinWait > retryMaxWait {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_min_wait"),
+			"Invalid Retry Wait",
+			"retry_min_wait ("+retryMinWait.String()+") must not be greater than retry_max_wait ("+retryMaxWait.String()+").",
+		)
+	}
+
+	maxRetries := int64(defaultMaxRetries)
+	if config.MaxRetries.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_retries"),
+			"Unknown Instatus Max Retries",
+			"The provider cannot create the Instatus API client as there is an unknown configuration value for max_retries. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	} else if !config.MaxRetries.IsNull() {
+		maxRetries = config.MaxRetries.ValueInt64()
+	}
+
+	var requestsPerSecond float64
+	if config.RequestsPerSecond.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("requests_per_second"),
+			"Unknown Instatus Requests Per Second",
+			"The provider cannot create the Instatus API client as there is an unknown configuration value for requests_per_second. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	} else if !config.RequestsPerSecond.IsNull() {
+		requestsPerSecond = config.RequestsPerSecond.ValueFloat64()
+	}
+
+	endpoint, err := parseAPIEndpoint(apiEndpoint)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_endpoint"),
+			"Invalid Instatus API Endpoint",
+			"The provider cannot create the Instatus API client as the Instatus API Endpoint is invalid: "+err.Error(),
+		)
+	}
+
+	var proxy *url.URL
+	if config.ProxyURL.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("proxy_url"),
+			"Unknown Proxy URL",
+			"The provider cannot create the Instatus API client as there is an unknown configuration value for proxy_url. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the HTTPS_PROXY environment variable.",
+		)
+	} else if !config.ProxyURL.IsNull() {
+		proxy, err = parseProxyURL(config.ProxyURL.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("proxy_url"),
+				"Invalid Proxy URL",
+				"The provider cannot create the Instatus API client as the proxy URL is invalid: "+err.Error(),
+			)
+		}
+	}
+
+	if config.CACertFile.IsUnknown() || config.InsecureSkipVerify.IsUnknown() {
+		resp.Diagnostics.AddError(
+			"Unknown TLS Configuration",
+			"The provider cannot create the Instatus API client as there is an unknown configuration value for ca_cert_file or insecure_skip_verify. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	tlsConfig, err := newTLSConfig(config.CACertFile.ValueString(), config.InsecureSkipVerify.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ca_cert_file"),
+			"Invalid CA Certificate File",
+			"The provider cannot create the Instatus API client as the CA certificate file could not be loaded: "+err.Error(),
+		)
+	}
+
+	if config.UserAgentSuffix.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("user_agent_suffix"),
+			"Unknown User-Agent Suffix",
+			"The provider cannot create the Instatus API client as there is an unknown configuration value for user_agent_suffix. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.DefaultPageID.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_page_id"),
+			"Unknown Default Page ID",
+			"The provider cannot create the Instatus API client as there is an unknown configuration value for default_page_id. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.Parallelism.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("parallelism"),
+			"Unknown Instatus Parallelism",
+			"The provider cannot create the Instatus API client as there is an unknown configuration value for parallelism. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	cacheTTL := parseDurationAttribute(config.CacheTTL, path.Root("cache_ttl"), 0, &resp.Diagnostics)
+
+	circuitBreakerThreshold := int64(defaultCircuitBreakerThreshold)
+	if config.CircuitBreakerThreshold.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("circuit_breaker_threshold"),
+			"Unknown Instatus Circuit Breaker Threshold",
+			"The provider cannot create the Instatus API client as there is an unknown configuration value for circuit_breaker_threshold. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	} else if !config.CircuitBreakerThreshold.IsNull() {
+		circuitBreakerThreshold = config.CircuitBreakerThreshold.ValueInt64()
+	}
+
+	if config.MetricsFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("metrics_file"),
+			"Unknown Metrics File",
+			"The provider cannot create the Instatus API client as there is an unknown configuration value for metrics_file. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.TolerateReadErrors.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("tolerate_read_errors"),
+			"Unknown Tolerate Read Errors",
+			"The provider cannot create the Instatus API client as there is an unknown configuration value for tolerate_read_errors. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.ReadOnly.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("read_only"),
+			"Unknown Read Only Mode",
+			"The provider cannot create the Instatus API client as there is an unknown configuration value for read_only. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	userAgent := fmt.Sprintf("terraform-provider-instatus/%s Terraform/%s", p.version, req.TerraformVersion)
+	if suffix := strings.TrimSpace(config.UserAgentSuffix.ValueString()); suffix != "" {
+		userAgent += " " + suffix
+	}
+
 	// Create a new Instatus client using the configuration values
-	client := is.NewClient(apiKey)
+	quota := &quotaMonitor{}
+
+	var metrics *apiMetrics
+	if config.MetricsFile.ValueString() != "" {
+		metrics = &apiMetrics{
+			logCtx: ctx,
+			path:   config.MetricsFile.ValueString(),
+		}
+		p.mu.Lock()
+		p.metrics = append(p.metrics, metrics)
+		p.mu.Unlock()
+	}
+
+	httpClient := newHTTPClient(httpClientConfig{
+		apiKeys:                 apiKeys,
+		endpoint:                endpoint,
+		connectTimeout:          connectTimeout,
+		requestTimeout:          requestTimeout,
+		maxRetries:              int(maxRetries),
+		retryMinWait:            retryMinWait,
+		retryMaxWait:            retryMaxWait,
+		rateLimit:               requestsPerSecond,
+		proxy:                   proxy,
+		tlsConfig:               tlsConfig,
+		userAgent:               userAgent,
+		logRequests:             config.LogRequests.ValueBool(),
+		readOnly:                config.ReadOnly.ValueBool(),
+		parallelism:             int(config.Parallelism.ValueInt64()),
+		circuitBreakerThreshold: int(circuitBreakerThreshold),
+		cacheTTL:                cacheTTL,
+		quota:                   quota,
+		metrics:                 metrics,
+	})
+
+	newClient := newClientFactory(apiKeys, httpClient)
+	data := &instatusProviderData{
+		newClient:          newClient,
+		defaultPageID:      config.DefaultPageID.ValueString(),
+		components:         &componentBatch{newClient: newClient},
+		pageURLs:           &pageURLs{},
+		quota:              quota,
+		tolerateReadErrors: config.TolerateReadErrors.ValueBool(),
+	}
+
+	// Make a cheap authenticated call so an invalid or expired API key is
+	// reported once here rather than by every resource. Only 401 and 403
+	// responses prove the key wrong: other failures, like the API being
+	// unreachable, are left to the resources, where tolerate_read_errors
+	// applies.
+	if _, err := data.Client(ctx).GetUser(); err != nil {
+		if isAuthError(err) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("api_key"),
+				"Unable to Authenticate with Instatus",
+				"The provider could not authenticate with the Instatus API using the configured API Key. "+
+					"Ensure the key is valid and has not been revoked.\n\n"+
+					"Instatus client error: "+err.Error(),
+			)
+			return
+		}
+
+		var probeDiags diag.Diagnostics
+		addAPIError(&probeDiags, "Unable to Reach Instatus", "Could not check the configured API Key, the provider continues without checking it", err)
+		for _, d := range probeDiags {
+			resp.Diagnostics.AddWarning(d.Summary(), d.Detail())
+		}
+	}
 
-	// Make the Instatus client available during DataSource and Resource
-	// type Configure methods.
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	// Make the Instatus client available during DataSource, Resource and
+	// ListResource type Configure methods.
+	resp.DataSourceData = data
+	resp.ResourceData = data
+	resp.ListResourceData = data
 }
 
 // DataSources defines the data sources implemented in the provider.
 func (p *instatusProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
+		NewComponentsDataSource,
 		NewUserDataSource,
 	}
 }
@@ -121,6 +628,82 @@ func (p *instatusProvider) DataSources(_ context.Context) []func() datasource.Da
 func (p *instatusProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewComponentResource,
+		NewComponentsResource,
 		NewTemplateResource,
 	}
 }
+
+// ListResources defines the list resources implemented in the provider.
+func (p *instatusProvider) ListResources(_ context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewComponentListResource,
+	}
+}
+
+// parseDurationAttribute parses a duration string attribute, returning
+// fallback when it is not set.
+func parseDurationAttribute(value types.String, attributePath path.Path, fallback time.Duration, diags *diag.Diagnostics) time.Duration {
+	if value.IsUnknown() {
+		diags.AddAttributeError(
+			attributePath,
+			"Unknown Duration",
+			"The provider cannot create the Instatus API client as there is an unknown configuration value for "+attributePath.String()+". "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+		return fallback
+	}
+
+	if value.IsNull() {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(value.ValueString())
+	if err != nil || duration < 0 {
+		diags.AddAttributeError(
+			attributePath,
+			"Invalid Duration",
+			"Expected a non-negative duration string such as \"30s\" or \"2m\", got: "+value.ValueString(),
+		)
+		return fallback
+	}
+
+	return duration
+}
+
+// planDefaultPageID sets the planned page_id of a resource to the provider
+// default_page_id when it is omitted from the configuration.
+func planDefaultPageID(ctx context.Context, defaultPageID string, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do when the resource is being destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var pageID types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("page_id"), &pageID)...)
+	if resp.Diagnostics.HasError() || !pageID.IsNull() {
+		return
+	}
+
+	if defaultPageID == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("page_id"),
+			"Missing Page ID",
+			"The page_id attribute must be set on the resource, or default_page_id must be set on the provider.",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("page_id"), defaultPageID)...)
+
+	// Objects can't move between pages, so a changed default_page_id
+	// replaces the objects relying on it.
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var priorPageID types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("page_id"), &priorPageID)...)
+	if !priorPageID.IsNull() && priorPageID.ValueString() != defaultPageID {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("page_id"))
+	}
+}
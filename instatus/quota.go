@@ -0,0 +1,98 @@
+package instatus
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// quotaWarningRatio is the share of the rate limit quota below which a
+// warning is reported.
+const quotaWarningRatio = 0.1
+
+// quotaMonitor tracks the rate limit quota reported by the Instatus API, so
+// operators learn why an apply slows down before requests get rejected.
+type quotaMonitor struct {
+	mu        sync.Mutex
+	limit     int
+	remaining int
+	// warned is set once the low quota was reported, until it recovers.
+	warned bool
+}
+
+// observe records the quota headers of a response.
+func (m *quotaMonitor) observe(resp *http.Response) {
+	limit, okLimit := headerInt(resp, "X-RateLimit-Limit", "RateLimit-Limit")
+	remaining, okRemaining := headerInt(resp, "X-RateLimit-Remaining", "RateLimit-Remaining")
+	if !okLimit || !okRemaining || limit <= 0 {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.limit = limit
+	m.remaining = remaining
+	if !m.low() {
+		m.warned = false
+	}
+}
+
+// Diagnostics returns a warning the first time the quota is found low.
+func (m *quotaMonitor) Diagnostics() diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.warned || !m.low() {
+		return diags
+	}
+	m.warned = true
+
+	diags.AddWarning(
+		"Instatus API Rate Limit Nearly Exhausted",
+		fmt.Sprintf("Only %d of %d Instatus API requests remain in the current rate limit window. "+
+			"Further requests will be retried as the limit resets, which slows down the run. "+
+			"Consider lowering requests_per_second in the provider configuration or splitting the configuration into smaller runs.",
+			m.remaining, m.limit),
+	)
+
+	return diags
+}
+
+// low reports whether the remaining quota is below the warning ratio. It
+// must be called with mu held.
+func (m *quotaMonitor) low() bool {
+	return m.limit > 0 && float64(m.remaining) < float64(m.limit)*quotaWarningRatio
+}
+
+// quotaTransport feeds the responses of the Instatus API to a quotaMonitor.
+type quotaTransport struct {
+	monitor   *quotaMonitor
+	transport http.RoundTripper
+}
+
+// RoundTrip sends the request and observes the quota headers of the response.
+func (t *quotaTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.transport.RoundTrip(req)
+	if err == nil {
+		t.monitor.observe(resp)
+	}
+
+	return resp, err
+}
+
+// headerInt returns the integer value of the first of the given headers
+// present in resp.
+func headerInt(resp *http.Response, headers ...string) (int, bool) {
+	for _, header := range headers {
+		if value := resp.Header.Get(header); value != "" {
+			n, err := strconv.Atoi(value)
+			return n, err == nil
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,178 @@
+package instatus
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	is "github.com/brunoscota/instatus-client-go"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	defaultMaxRetries   = 4
+	defaultRetryMinWait = 1 * time.Second
+	defaultRetryMaxWait = 30 * time.Second
+
+	readAfterWriteTimeout = 30 * time.Second
+	readAfterWriteMinWait = 500 * time.Millisecond
+)
+
+// retryClient retries Instatus API requests that failed because of rate
+// limiting, transient server errors or network errors, waiting with an
+// exponential backoff between attempts.
+type retryClient struct {
+	client     is.HTTPClient
+	maxRetries int
+	minWait    time.Duration
+	maxWait    time.Duration
+}
+
+// Do sends the request, retrying it until it succeeds, fails permanently or
+// the retry budget is exhausted.
+func (c *retryClient) Do(req *http.Request) (*http.Response, error) {
+	maxRetries := c.maxRetries
+	if override, ok := req.Context().Value(maxRetriesKey{}).(int); ok {
+		maxRetries = override
+	}
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		resp, err := c.client.Do(req)
+		if attempt >= maxRetries || !shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		wait := c.backoff(attempt)
+		if resp != nil {
+			if retryAfter, ok := parseRetryAfter(resp); ok {
+				wait = retryAfter
+			}
+
+			// Drain the body so the connection can be reused.
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// maxRetriesKey is the context key of a per-resource max_retries override.
+type maxRetriesKey struct{}
+
+// withMaxRetries overrides the provider max_retries for the requests sent
+// with the returned context, unless maxRetries is null or unknown.
+func withMaxRetries(ctx context.Context, maxRetries types.Int64) context.Context {
+	if maxRetries.IsNull() || maxRetries.IsUnknown() {
+		return ctx
+	}
+
+	return context.WithValue(ctx, maxRetriesKey{}, int(maxRetries.ValueInt64()))
+}
+
+// backoff returns the time to wait before the given retry attempt.
+func (c *retryClient) backoff(attempt int) time.Duration {
+	wait := c.minWait << attempt
+	if wait < c.minWait || wait > c.maxWait {
+		wait = c.maxWait
+	}
+
+	// Add up to 25% of jitter so parallel operations don't retry in lockstep.
+	if jitter := int64(wait / 4); jitter > 0 {
+		wait += time.Duration(rand.Int63n(jitter))
+	}
+
+	return wait
+}
+
+// parseRetryAfter returns the wait requested by the Retry-After header of a
+// rate-limited or unavailable response, given either in seconds or as an
+// HTTP date.
+func parseRetryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable {
+		return 0, false
+	}
+
+	header := resp.Header.Get("Retry-After")
+	if header == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(header); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+// shouldRetry reports whether a request is worth retrying. Network errors
+// and server errors are only retried for idempotent methods, since a create
+// may have reached the API before failing. Creates are only retried when the
+// API turned them away, with a 429 or 503 response.
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		if req.Context().Err() != nil {
+			return false
+		}
+		return req.Method != http.MethodPost
+	}
+
+	if req.Method == http.MethodPost {
+		return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable
+	}
+
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+}
+
+// retryNotFound calls read until it stops failing with a 404, which the API
+// may return for a short while after an object was created.
+func retryNotFound(ctx context.Context, read func() error) error {
+	deadline := time.Now().Add(readAfterWriteTimeout)
+	wait := readAfterWriteMinWait
+
+	for {
+		err := read()
+		if !isNotFound(err) || time.Now().Add(wait).After(deadline) {
+			return err
+		}
+
+		tflog.Debug(ctx, "Instatus object not readable yet after being written, retrying", map[string]interface{}{
+			"wait": wait.String(),
+		})
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		wait *= 2
+	}
+}
@@ -0,0 +1,239 @@
+package instatus
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// handlerClient answers requests with handle, recording a copy of each
+// request along with its body.
+type handlerClient struct {
+	handle   func(req *http.Request) (*http.Response, error)
+	requests []*http.Request
+	bodies   []string
+}
+
+func (c *handlerClient) Do(req *http.Request) (*http.Response, error) {
+	var body string
+	if req.Body != nil {
+		b, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		body = string(b)
+	}
+	c.requests = append(c.requests, req.Clone(req.Context()))
+	c.bodies = append(c.bodies, body)
+
+	return c.handle(req)
+}
+
+// statusSequence returns a handler answering with the next status code of
+// statuses, repeating the last one. The response headers are set from
+// header, if not nil.
+func statusSequence(header http.Header, statuses ...int) func(req *http.Request) (*http.Response, error) {
+	requests := 0
+
+	return func(req *http.Request) (*http.Response, error) {
+		status := statuses[min(requests, len(statuses)-1)]
+		requests++
+
+		return newTestResponse(req, status, header, "{}"), nil
+	}
+}
+
+// newTestResponse returns a response to req.
+func newTestResponse(req *http.Request, status int, header http.Header, body string) *http.Response {
+	if header == nil {
+		header = make(http.Header)
+	}
+
+	return &http.Response{
+		StatusCode: status,
+		Header:     header.Clone(),
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}
+}
+
+// newTestRetryClient returns a retry client whose backoff is short enough
+// for tests.
+func newTestRetryClient(client *handlerClient, maxRetries int) *retryClient {
+	return &retryClient{
+		client:     client,
+		maxRetries: maxRetries,
+		minWait:    time.Millisecond,
+		maxWait:    time.Millisecond,
+	}
+}
+
+func TestRetryClientRetries(t *testing.T) {
+	tests := map[string]struct {
+		method       string
+		statuses     []int
+		maxRetries   int
+		wantRequests int
+		wantStatus   int
+	}{
+		"read recovers": {
+			method:       http.MethodGet,
+			statuses:     []int{http.StatusBadGateway, http.StatusOK},
+			maxRetries:   3,
+			wantRequests: 2,
+			wantStatus:   http.StatusOK,
+		},
+		"read out of retries": {
+			method:       http.MethodGet,
+			statuses:     []int{http.StatusInternalServerError},
+			maxRetries:   2,
+			wantRequests: 3,
+			wantStatus:   http.StatusInternalServerError,
+		},
+		"read not found": {
+			method:       http.MethodGet,
+			statuses:     []int{http.StatusNotFound},
+			maxRetries:   3,
+			wantRequests: 1,
+			wantStatus:   http.StatusNotFound,
+		},
+		"create failing": {
+			method:       http.MethodPost,
+			statuses:     []int{http.StatusInternalServerError, http.StatusCreated},
+			maxRetries:   3,
+			wantRequests: 1,
+			wantStatus:   http.StatusInternalServerError,
+		},
+		"create rate limited": {
+			method:       http.MethodPost,
+			statuses:     []int{http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusCreated},
+			maxRetries:   3,
+			wantRequests: 3,
+			wantStatus:   http.StatusCreated,
+		},
+		"retries disabled": {
+			method:       http.MethodGet,
+			statuses:     []int{http.StatusTooManyRequests, http.StatusOK},
+			maxRetries:   0,
+			wantRequests: 1,
+			wantStatus:   http.StatusTooManyRequests,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &handlerClient{handle: statusSequence(nil, test.statuses...)}
+
+			resp, err := newTestRetryClient(client, test.maxRetries).Do(newTestRequest(t, test.method))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.StatusCode != test.wantStatus {
+				t.Errorf("expected status %d, got %d", test.wantStatus, resp.StatusCode)
+			}
+			if len(client.requests) != test.wantRequests {
+				t.Errorf("expected %d requests, got %d", test.wantRequests, len(client.requests))
+			}
+		})
+	}
+}
+
+func TestRetryClientHonorsRetryAfter(t *testing.T) {
+	header := http.Header{"Retry-After": []string{"0"}}
+	client := &handlerClient{handle: statusSequence(header, http.StatusTooManyRequests, http.StatusOK)}
+	// Without the Retry-After header, the first retry would wait an hour.
+	retry := &retryClient{client: client, maxRetries: 1, minWait: time.Hour, maxWait: time.Hour}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := retry.Do(newTestRequest(t, http.MethodGet).WithContext(ctx))
+	if err != nil {
+		t.Fatalf("expected the Retry-After wait to be used, got %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || len(client.requests) != 2 {
+		t.Errorf("expected a successful retry, got status %d after %d requests", resp.StatusCode, len(client.requests))
+	}
+}
+
+func TestRetryClientResendsBody(t *testing.T) {
+	client := &handlerClient{handle: statusSequence(nil, http.StatusServiceUnavailable, http.StatusOK)}
+
+	req, err := http.NewRequest(http.MethodPut, defaultAPIEndpoint+"/"+testPageID+"/components/api", strings.NewReader(`{"name":"API"}`))
+	if err != nil {
+		t.Fatalf("creating request: %v", err)
+	}
+	if _, err := newTestRetryClient(client, 1).Do(req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i, body := range client.bodies {
+		if body != `{"name":"API"}` {
+			t.Errorf("request %d: expected the body to be sent again, got %q", i, body)
+		}
+	}
+}
+
+func TestRetryClientMaxRetriesOverride(t *testing.T) {
+	client := &handlerClient{handle: statusSequence(nil, http.StatusBadGateway)}
+	ctx := withMaxRetries(context.Background(), types.Int64Value(1))
+
+	if _, err := newTestRetryClient(client, 5).Do(newTestRequest(t, http.MethodGet).WithContext(ctx)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(client.requests) != 2 {
+		t.Errorf("expected the max_retries of the resource to apply, got %d requests", len(client.requests))
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := map[string]struct {
+		status   int
+		header   string
+		wantWait time.Duration
+		wantOK   bool
+	}{
+		"seconds": {
+			status:   http.StatusTooManyRequests,
+			header:   "7",
+			wantWait: 7 * time.Second,
+			wantOK:   true,
+		},
+		"past date": {
+			status:   http.StatusServiceUnavailable,
+			header:   "Wed, 21 Oct 2015 07:28:00 GMT",
+			wantWait: 0,
+			wantOK:   true,
+		},
+		"missing": {
+			status: http.StatusTooManyRequests,
+		},
+		"invalid": {
+			status: http.StatusTooManyRequests,
+			header: "soon",
+		},
+		"server error": {
+			status: http.StatusInternalServerError,
+			header: "7",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			header := make(http.Header)
+			if test.header != "" {
+				header.Set("Retry-After", test.header)
+			}
+
+			wait, ok := parseRetryAfter(&http.Response{StatusCode: test.status, Header: header})
+			if wait != test.wantWait || ok != test.wantOK {
+				t.Errorf("expected %s, %t, got %s, %t", test.wantWait, test.wantOK, wait, ok)
+			}
+		})
+	}
+}
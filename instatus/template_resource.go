@@ -7,6 +7,8 @@ import (
 	"time"
 
 	is "github.com/brunoscota/instatus-client-go"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -15,6 +17,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -22,6 +25,9 @@ var (
 	_ resource.Resource                = &templateResource{}
 	_ resource.ResourceWithConfigure   = &templateResource{}
 	_ resource.ResourceWithImportState = &templateResource{}
+	_ resource.ResourceWithModifyPlan  = &templateResource{}
+
+	_ resource.ResourceWithUpgradeState = &templateResource{}
 )
 
 // Configure adds the provider configured client to the resource.
@@ -30,7 +36,8 @@ func (r *templateResource) Configure(_ context.Context, req resource.ConfigureRe
 		return
 	}
 
-	r.client = req.ProviderData.(*is.Client)
+	r.provider = req.ProviderData.(*instatusProviderData)
+	r.defaultPageID = r.provider.defaultPageID
 }
 
 // NewTemplateResource is a helper function to simplify the provider implementation.
@@ -40,7 +47,8 @@ func NewTemplateResource() resource.Resource {
 
 // templateResource is the resource implementation.
 type templateResource struct {
-	client *is.Client
+	provider      *instatusProviderData
+	defaultPageID string
 }
 
 // templateResourceModel maps the resource schema data.
@@ -55,6 +63,8 @@ type templateResourceModel struct {
 	Components  []templateComponentModel `tfsdk:"components"`
 	Notify      types.Bool               `tfsdk:"notify"`
 	LastUpdated types.String             `tfsdk:"last_updated"`
+	MaxRetries  types.Int64              `tfsdk:"max_retries"`
+	Timeouts    timeouts.Value           `tfsdk:"timeouts"`
 }
 
 type templateComponentModel struct {
@@ -68,13 +78,14 @@ func (r *templateResource) Metadata(_ context.Context, req resource.MetadataRequ
 }
 
 // Schema defines the schema for the resource.
-func (r *templateResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *templateResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	componentStatuses := []string{"OPERATIONAL", "UNDERMAINTENANCE", "DEGRADEDPERFORMANCE", "PARTIALOUTAGE", "MAJOROUTAGE"}
 	templateStatuses := []string{"INVESTIGATING", "IDENTIFIED", "MONITORING", "RESOLVED", "NOTSTARTEDYET", "INPROGRESS", "COMPLETED"}
 	templateTypes := []string{"MAINTENANCE", "INCIDENT"}
 
 	resp.Schema = schema.Schema{
 		Description: "Manages a template.",
+		Version:     1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "String Identifier of the template.",
@@ -84,8 +95,9 @@ func (r *templateResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 				},
 			},
 			"page_id": schema.StringAttribute{
-				Description: "String Identifier of the page of the template.",
-				Required:    true,
+				Description: "String Identifier of the page of the template. Defaults to the provider default_page_id.",
+				Optional:    true,
+				Computed:    true,
 			},
 			"subdomain": schema.StringAttribute{
 				Description: "Subdomain of the page of the template.",
@@ -113,6 +125,13 @@ func (r *templateResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 				Description: "Message of the template.",
 				Required:    true,
 			},
+			"max_retries": schema.Int64Attribute{
+				Description: "Maximum number of times the API requests of this template are retried, overriding the provider max_retries. Set to 0 to disable retries.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
 			"notify": schema.BoolAttribute{
 				Description: "Whether notify is enabled for the template.",
 				Optional:    true,
@@ -135,9 +154,38 @@ func (r *templateResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// UpgradeState upgrades states written by earlier versions of the resource.
+func (r *templateResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	// Version 1 made page_id optional, defaulting to the provider
+	// default_page_id, and added max_retries and the timeouts block, left
+	// null for existing templates.
+	return map[int64]resource.StateUpgrader{
+		0: {
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				upgradeStateToSchema(ctx, schemaResp.Schema, nil, req, resp)
+			},
+		},
 	}
 }
 
+// ModifyPlan fills in the page_id from the provider configuration when omitted.
+func (r *templateResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planDefaultPageID(ctx, r.defaultPageID, req, resp)
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *templateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	// Retrieve values from plan
@@ -148,6 +196,17 @@ func (r *templateResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	ctx = withMaxRetries(ctx, plan.MaxRetries)
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	var components []is.TemplateComponent
 	for _, component := range plan.Components {
 		components = append(components, is.TemplateComponent{
@@ -167,16 +226,34 @@ func (r *templateResource) Create(ctx context.Context, req resource.CreateReques
 	}
 
 	// Create new template
-	template, err := r.client.CreateTemplate(plan.PageID.ValueString(), &item)
+	template, err := r.provider.Client(ctx).CreateTemplate(plan.PageID.ValueString(), &item)
+	resp.Diagnostics.Append(r.provider.quota.Diagnostics()...)
 
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addAPIError(
+			&resp.Diagnostics,
 			"Error creating template",
-			"Could not create template, unexpected error: "+err.Error(),
+			"Could not create template",
+			err,
 		)
 		return
 	}
 
+	// Wait until the template can be read back, as the API may briefly
+	// report a new template as missing.
+	if template.ID != nil {
+		err = retryNotFound(ctx, func() error {
+			_, err := r.provider.Client(ctx).GetTemplate(plan.PageID.ValueString(), *template.ID)
+			return err
+		})
+		if err != nil {
+			resp.Diagnostics.AddWarning(
+				"Instatus Template Not Readable After Creation",
+				"Template ID "+*template.ID+" was created but could not be read back: "+err.Error(),
+			)
+		}
+	}
+
 	// Map response body to schema and populate Computed attribute values
 	plan.ID = types.StringPointerValue(template.ID)
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
@@ -199,12 +276,33 @@ func (r *templateResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
+	ctx = withMaxRetries(ctx, state.MaxRetries)
+
 	// Get refreshed template value from Instatus
-	template, err := r.client.GetTemplate(state.PageID.ValueString(), state.ID.ValueString())
+	template, err := r.provider.Client(ctx).GetTemplate(state.PageID.ValueString(), state.ID.ValueString())
+	resp.Diagnostics.Append(r.provider.quota.Diagnostics()...)
+	if isNotFound(err) {
+		// The template was deleted outside of Terraform, let the next plan
+		// recreate it.
+		tflog.Warn(ctx, "Instatus template not found, removing it from state", map[string]interface{}{
+			"id": state.ID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil && r.provider.tolerateReadErrors && isTransientError(err) {
+		resp.Diagnostics.AddWarning(
+			"Instatus Template Not Refreshed",
+			"Could not reach the Instatus API to refresh template ID "+state.ID.ValueString()+", the previous state is kept: "+err.Error(),
+		)
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addAPIError(
+			&resp.Diagnostics,
 			"Error Reading Instatus Template",
-			"Could not read Instatus template ID "+state.ID.ValueString()+": "+err.Error(),
+			"Could not read Instatus template ID "+state.ID.ValueString(),
+			err,
 		)
 		return
 	}
@@ -240,6 +338,17 @@ func (r *templateResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	ctx = withMaxRetries(ctx, plan.MaxRetries)
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	// Generate API request body from plan
 	var components []is.TemplateComponent
 	for _, component := range plan.Components {
@@ -260,11 +369,14 @@ func (r *templateResource) Update(ctx context.Context, req resource.UpdateReques
 	}
 
 	// Update existing template
-	_, err := r.client.UpdateTemplate(plan.PageID.ValueString(), plan.ID.ValueString(), &item)
+	_, err := r.provider.Client(ctx).UpdateTemplate(plan.PageID.ValueString(), plan.ID.ValueString(), &item)
+	resp.Diagnostics.Append(r.provider.quota.Diagnostics()...)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addAPIError(
+			&resp.Diagnostics,
 			"Error Updating Instatus Template",
-			"Could not update template, unexpected error: "+err.Error(),
+			"Could not update template",
+			err,
 		)
 		return
 	}
@@ -290,12 +402,26 @@ func (r *templateResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
+	ctx = withMaxRetries(ctx, state.MaxRetries)
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultOperationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// Delete existing template
-	err := r.client.DeleteTemplate(state.PageID.ValueString(), state.ID.ValueString())
+	err := r.provider.Client(ctx).DeleteTemplate(state.PageID.ValueString(), state.ID.ValueString())
+	resp.Diagnostics.Append(r.provider.quota.Diagnostics()...)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addAPIError(
+			&resp.Diagnostics,
 			"Error Deleting Instatus Template",
-			"Could not delete template, unexpected error: "+err.Error(),
+			"Could not delete template",
+			err,
 		)
 		return
 	}
@@ -0,0 +1,402 @@
+package instatus
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	is "github.com/brunoscota/instatus-client-go"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// fakeTemplateClient is an in-memory Instatus API for templates. The
+// component methods of the embedded interface are not implemented.
+type fakeTemplateClient struct {
+	instatusClient
+
+	templates map[string]*is.TemplateFull
+	// err fails every template call when set.
+	err error
+}
+
+// newFakeTemplateClient returns a page with the outage template, marking the
+// api component down.
+func newFakeTemplateClient() *fakeTemplateClient {
+	c := &fakeTemplateClient{templates: make(map[string]*is.TemplateFull)}
+	c.templates["outage"] = &is.TemplateFull{
+		ID:       stringPointer("outage"),
+		Template: testTemplate("Outage"),
+	}
+
+	return c
+}
+
+// testTemplate returns a template named name, as the API returns it.
+func testTemplate(name string) is.Template {
+	return is.Template{
+		Name:    stringPointer(name),
+		Type:    stringPointer("INCIDENT"),
+		Message: stringPointer("We are investigating"),
+		Status:  stringPointer("INVESTIGATING"),
+		Notify:  boolPointer(true),
+		Components: []is.TemplateComponent{
+			{ComponentID: stringPointer("api"), Status: stringPointer("MAJOROUTAGE")},
+		},
+	}
+}
+
+func (c *fakeTemplateClient) CreateTemplate(pageID string, template *is.Template) (*is.TemplateFull, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	id := fmt.Sprintf("template-%d", len(c.templates))
+	c.templates[id] = &is.TemplateFull{ID: &id}
+
+	return c.UpdateTemplate(pageID, id, template)
+}
+
+func (c *fakeTemplateClient) GetTemplate(pageID, templateID string) (*is.TemplateFull, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	template, ok := c.templates[templateID]
+	if !ok {
+		return nil, &apiError{StatusCode: 404, Method: "GET", Path: "/v1/" + pageID + "/templates/" + templateID}
+	}
+	copied := *template
+
+	return &copied, nil
+}
+
+func (c *fakeTemplateClient) UpdateTemplate(pageID, templateID string, template *is.Template) (*is.TemplateFull, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	stored, ok := c.templates[templateID]
+	if !ok {
+		return nil, &apiError{StatusCode: 404, Method: "PUT", Path: "/v1/" + pageID + "/templates/" + templateID}
+	}
+
+	// The API returns the components of a template by componentId.
+	stored.Template = *template
+	stored.Components = nil
+	for _, component := range template.Components {
+		stored.Components = append(stored.Components, is.TemplateComponent{ComponentID: component.ID, Status: component.Status})
+	}
+
+	return c.GetTemplate(pageID, templateID)
+}
+
+func (c *fakeTemplateClient) DeleteTemplate(pageID, templateID string) error {
+	if c.err != nil {
+		return c.err
+	}
+	if _, ok := c.templates[templateID]; !ok {
+		return &apiError{StatusCode: 404, Method: "DELETE", Path: "/v1/" + pageID + "/templates/" + templateID}
+	}
+	delete(c.templates, templateID)
+
+	return nil
+}
+
+// newTestTemplateResource returns a template resource using client, and its
+// schema.
+func newTestTemplateResource(client instatusClient) (*templateResource, resource.SchemaResponse) {
+	r := &templateResource{
+		provider: &instatusProviderData{
+			newClient: func(context.Context) instatusClient { return client },
+			quota:     &quotaMonitor{},
+		},
+	}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+	return r, schemaResp
+}
+
+// testTemplateModel returns the state of the outage template.
+func testTemplateModel() templateResourceModel {
+	return templateResourceModel{
+		ID:          types.StringValue("outage"),
+		PageID:      types.StringValue(testPageID),
+		Subdomain:   types.StringValue("example"),
+		Name:        types.StringValue("Outage"),
+		Type:        types.StringValue("INCIDENT"),
+		Message:     types.StringValue("We are investigating"),
+		Status:      types.StringValue("INVESTIGATING"),
+		Notify:      types.BoolValue(true),
+		LastUpdated: types.StringValue("Thursday, 01-Jan-26 00:00:00 UTC"),
+		MaxRetries:  types.Int64Null(),
+		Components: []templateComponentModel{
+			{ID: types.StringValue("api"), Status: types.StringValue("MAJOROUTAGE")},
+		},
+		Timeouts: timeouts.Value{Object: types.ObjectNull(map[string]attr.Type{
+			"create": types.StringType,
+			"update": types.StringType,
+			"delete": types.StringType,
+		})},
+	}
+}
+
+func TestTemplateCreate(t *testing.T) {
+	tests := map[string]struct {
+		err       error
+		wantError bool
+	}{
+		"created": {},
+		"rejected": {
+			err:       &apiError{StatusCode: 422, Method: "POST", Path: "/v1/page/templates", Message: "invalid status"},
+			wantError: true,
+		},
+		"unauthorized": {
+			err:       &apiError{StatusCode: 401, Method: "POST", Path: "/v1/page/templates"},
+			wantError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			client := newFakeTemplateClient()
+			client.err = test.err
+			r, schemaResp := newTestTemplateResource(client)
+
+			plan := testTemplateModel()
+			plan.ID = types.StringUnknown()
+			plan.Name = types.StringValue("Maintenance")
+			plan.LastUpdated = types.StringUnknown()
+
+			req := resource.CreateRequest{Plan: tfsdk.Plan{Schema: schemaResp.Schema}}
+			if diags := req.Plan.Set(ctx, plan); diags.HasError() {
+				t.Fatalf("setting plan: %v", diags)
+			}
+			resp := resource.CreateResponse{
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+			}
+			r.Create(ctx, req, &resp)
+			if resp.Diagnostics.HasError() != test.wantError {
+				t.Fatalf("expected error %t, got %v", test.wantError, resp.Diagnostics)
+			}
+			if test.wantError {
+				if !resp.State.Raw.IsNull() {
+					t.Errorf("expected no state, got %v", resp.State.Raw)
+				}
+				return
+			}
+
+			var state templateResourceModel
+			if diags := resp.State.Get(ctx, &state); diags.HasError() {
+				t.Fatalf("getting state: %v", diags)
+			}
+			created, ok := client.templates[state.ID.ValueString()]
+			if !ok {
+				t.Fatalf("expected template %s to be created, got %v", state.ID, client.templates)
+			}
+			if stringValue(created.Name) != "Maintenance" {
+				t.Errorf("expected template Maintenance to be created, got %s", stringValue(created.Name))
+			}
+			if state.LastUpdated.IsUnknown() || state.LastUpdated.IsNull() {
+				t.Errorf("expected last_updated to be set, got %s", state.LastUpdated)
+			}
+		})
+	}
+}
+
+func TestTemplateRead(t *testing.T) {
+	tests := map[string]struct {
+		// change alters the template outside of Terraform.
+		change      func(client *fakeTemplateClient)
+		tolerate    bool
+		wantError   bool
+		wantWarning bool
+		wantRemoved bool
+		wantName    string
+		wantStatus  string
+	}{
+		"unchanged": {
+			wantName:   "Outage",
+			wantStatus: "MAJOROUTAGE",
+		},
+		"changed outside of Terraform": {
+			change: func(client *fakeTemplateClient) {
+				client.templates["outage"].Name = stringPointer("Partial outage")
+				client.templates["outage"].Components[0].Status = stringPointer("PARTIALOUTAGE")
+			},
+			wantName:   "Partial outage",
+			wantStatus: "PARTIALOUTAGE",
+		},
+		"deleted outside of Terraform": {
+			change: func(client *fakeTemplateClient) {
+				delete(client.templates, "outage")
+			},
+			wantRemoved: true,
+		},
+		"API failing": {
+			change: func(client *fakeTemplateClient) {
+				client.err = &apiError{StatusCode: 503, Method: "GET", Path: "/v1/page/templates/outage"}
+			},
+			wantError: true,
+		},
+		"API failing with tolerate_read_errors": {
+			change: func(client *fakeTemplateClient) {
+				client.err = &apiError{StatusCode: 503, Method: "GET", Path: "/v1/page/templates/outage"}
+			},
+			tolerate:    true,
+			wantWarning: true,
+			wantName:    "Outage",
+			wantStatus:  "MAJOROUTAGE",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			client := newFakeTemplateClient()
+			if test.change != nil {
+				test.change(client)
+			}
+			r, schemaResp := newTestTemplateResource(client)
+			r.provider.tolerateReadErrors = test.tolerate
+
+			req := resource.ReadRequest{State: tfsdk.State{Schema: schemaResp.Schema}}
+			if diags := req.State.Set(ctx, testTemplateModel()); diags.HasError() {
+				t.Fatalf("setting state: %v", diags)
+			}
+			resp := resource.ReadResponse{State: req.State}
+			r.Read(ctx, req, &resp)
+			if resp.Diagnostics.HasError() != test.wantError {
+				t.Fatalf("expected error %t, got %v", test.wantError, resp.Diagnostics)
+			}
+			if (resp.Diagnostics.WarningsCount() > 0) != test.wantWarning {
+				t.Errorf("expected warning %t, got %v", test.wantWarning, resp.Diagnostics)
+			}
+			if test.wantError {
+				return
+			}
+			if resp.State.Raw.IsNull() != test.wantRemoved {
+				t.Fatalf("expected removed from state %t, got state %v", test.wantRemoved, resp.State.Raw)
+			}
+			if test.wantRemoved {
+				return
+			}
+
+			var state templateResourceModel
+			if diags := resp.State.Get(ctx, &state); diags.HasError() {
+				t.Fatalf("getting state: %v", diags)
+			}
+			if state.Name.ValueString() != test.wantName {
+				t.Errorf("expected name %s, got %s", test.wantName, state.Name)
+			}
+			if len(state.Components) != 1 || state.Components[0].ID.ValueString() != "api" || state.Components[0].Status.ValueString() != test.wantStatus {
+				t.Errorf("expected component api %s, got %v", test.wantStatus, state.Components)
+			}
+		})
+	}
+}
+
+func TestTemplateUpdate(t *testing.T) {
+	tests := map[string]struct {
+		err       error
+		wantError bool
+	}{
+		"updated": {},
+		"deleted outside of Terraform": {
+			err:       &apiError{StatusCode: 404, Method: "PUT", Path: "/v1/page/templates/outage"},
+			wantError: true,
+		},
+		"forbidden": {
+			err:       &apiError{StatusCode: 403, Method: "PUT", Path: "/v1/page/templates/outage"},
+			wantError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			client := newFakeTemplateClient()
+			client.err = test.err
+			r, schemaResp := newTestTemplateResource(client)
+
+			plan := testTemplateModel()
+			plan.Message = types.StringValue("We have found the cause")
+			plan.Status = types.StringValue("IDENTIFIED")
+			plan.LastUpdated = types.StringUnknown()
+
+			req := resource.UpdateRequest{
+				Plan:  tfsdk.Plan{Schema: schemaResp.Schema},
+				State: tfsdk.State{Schema: schemaResp.Schema},
+			}
+			if diags := req.Plan.Set(ctx, plan); diags.HasError() {
+				t.Fatalf("setting plan: %v", diags)
+			}
+			if diags := req.State.Set(ctx, testTemplateModel()); diags.HasError() {
+				t.Fatalf("setting state: %v", diags)
+			}
+			resp := resource.UpdateResponse{State: req.State}
+			r.Update(ctx, req, &resp)
+			if resp.Diagnostics.HasError() != test.wantError {
+				t.Fatalf("expected error %t, got %v", test.wantError, resp.Diagnostics)
+			}
+			if test.wantError {
+				return
+			}
+
+			updated := client.templates["outage"]
+			if stringValue(updated.Message) != "We have found the cause" || stringValue(updated.Status) != "IDENTIFIED" {
+				t.Errorf("expected the new message and status to be sent, got %s and %s", stringValue(updated.Message), stringValue(updated.Status))
+			}
+			var state templateResourceModel
+			if diags := resp.State.Get(ctx, &state); diags.HasError() {
+				t.Fatalf("getting state: %v", diags)
+			}
+			if state.Status.ValueString() != "IDENTIFIED" {
+				t.Errorf("expected status IDENTIFIED in state, got %s", state.Status)
+			}
+		})
+	}
+}
+
+func TestTemplateDelete(t *testing.T) {
+	tests := map[string]struct {
+		err         error
+		wantError   bool
+		wantDeleted bool
+	}{
+		"deleted": {
+			wantDeleted: true,
+		},
+		"API failing": {
+			err:       &apiError{StatusCode: 500, Method: "DELETE", Path: "/v1/page/templates/outage"},
+			wantError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			client := newFakeTemplateClient()
+			client.err = test.err
+			r, schemaResp := newTestTemplateResource(client)
+
+			req := resource.DeleteRequest{State: tfsdk.State{Schema: schemaResp.Schema}}
+			if diags := req.State.Set(ctx, testTemplateModel()); diags.HasError() {
+				t.Fatalf("setting state: %v", diags)
+			}
+			resp := resource.DeleteResponse{State: req.State}
+			r.Delete(ctx, req, &resp)
+			if resp.Diagnostics.HasError() != test.wantError {
+				t.Fatalf("expected error %t, got %v", test.wantError, resp.Diagnostics)
+			}
+
+			if _, ok := client.templates["outage"]; ok == test.wantDeleted {
+				t.Errorf("expected deleted %t, got templates %v", test.wantDeleted, client.templates)
+			}
+		})
+	}
+}
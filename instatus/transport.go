@@ -0,0 +1,308 @@
+package instatus
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	is "github.com/brunoscota/instatus-client-go"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// defaultAPIEndpoint is the base URL the Instatus client sends requests to.
+	defaultAPIEndpoint = "https://api.instatus.com/v1"
+
+	defaultConnectTimeout = 10 * time.Second
+	defaultRequestTimeout = 60 * time.Second
+
+	// defaultOperationTimeout bounds a resource operation whose timeouts
+	// block leaves it unset.
+	defaultOperationTimeout = 20 * time.Minute
+)
+
+// httpClientConfig holds the provider settings that shape outgoing API requests.
+type httpClientConfig struct {
+	// apiKeys are tried in order when the API rejects a key.
+	apiKeys        []string
+	endpoint       *url.URL
+	connectTimeout time.Duration
+	requestTimeout time.Duration
+	maxRetries     int
+	retryMinWait   time.Duration
+	retryMaxWait   time.Duration
+	rateLimit      float64
+	proxy          *url.URL
+	tlsConfig      *tls.Config
+	userAgent      string
+	logRequests    bool
+	readOnly       bool
+	// parallelism bounds the number of concurrent requests, 0 means unlimited.
+	parallelism int
+	// circuitBreakerThreshold is the number of consecutive failures after
+	// which requests fail fast, 0 disables the circuit breaker.
+	circuitBreakerThreshold int
+	// cacheTTL is how long GET responses are reused without revalidation.
+	cacheTTL time.Duration
+	quota    *quotaMonitor
+	// metrics summarizes the API calls, if set.
+	metrics *apiMetrics
+}
+
+// newHTTPClient builds the HTTP client handed to the Instatus client.
+func newHTTPClient(config httpClientConfig) is.HTTPClient {
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	base.DialContext = (&net.Dialer{
+		Timeout:   config.connectTimeout,
+		KeepAlive: 30 * time.Second,
+	}).DialContext
+	base.TLSHandshakeTimeout = config.connectTimeout
+	if config.proxy != nil {
+		base.Proxy = http.ProxyURL(config.proxy)
+	}
+	if config.tlsConfig != nil {
+		base.TLSClientConfig = config.tlsConfig
+	}
+
+	var transport http.RoundTripper = base
+
+	if config.logRequests {
+		transport = &loggingTransport{
+			transport: transport,
+		}
+	}
+
+	if config.quota != nil {
+		transport = &quotaTransport{
+			monitor:   config.quota,
+			transport: transport,
+		}
+	}
+
+	transport = &endpointTransport{
+		endpoint:  config.endpoint,
+		transport: transport,
+	}
+
+	transport = &userAgentTransport{
+		userAgent: config.userAgent,
+		transport: transport,
+	}
+
+	if config.parallelism > 0 {
+		transport = &semaphoreTransport{
+			slots:     make(chan struct{}, config.parallelism),
+			transport: transport,
+		}
+	}
+
+	if config.rateLimit > 0 {
+		transport = &rateLimitTransport{
+			limiter:   rate.NewLimiter(rate.Limit(config.rateLimit), 1),
+			transport: transport,
+		}
+	}
+
+	var client is.HTTPClient = &retryClient{
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   config.requestTimeout,
+		},
+		maxRetries: config.maxRetries,
+		minWait:    config.retryMinWait,
+		maxWait:    config.retryMaxWait,
+	}
+
+	client = &idempotencyClient{
+		client: client,
+	}
+
+	if config.circuitBreakerThreshold > 0 {
+		client = &circuitBreakerClient{
+			client:    client,
+			threshold: config.circuitBreakerThreshold,
+		}
+	}
+
+	if len(config.apiKeys) > 1 {
+		client = &apiKeyFailoverClient{
+			client: client,
+			keys:   config.apiKeys,
+		}
+	}
+
+	client = &errorClient{
+		client: client,
+	}
+
+	if config.metrics != nil {
+		client = &metricsClient{
+			client:  client,
+			metrics: config.metrics,
+		}
+	}
+
+	client = &cacheClient{
+		client: client,
+		ttl:    config.cacheTTL,
+	}
+
+	if config.readOnly {
+		client = &readOnlyClient{
+			client: client,
+		}
+	}
+
+	return client
+}
+
+// contextClient binds the requests of an Instatus client to a context, as the
+// client itself creates them without one.
+type contextClient struct {
+	ctx    context.Context
+	client is.HTTPClient
+}
+
+// Do sends the request with the bound context.
+func (c *contextClient) Do(req *http.Request) (*http.Response, error) {
+	return c.client.Do(req.WithContext(c.ctx))
+}
+
+// readOnlyClient refuses every request that could modify Instatus data.
+type readOnlyClient struct {
+	client is.HTTPClient
+}
+
+// Do sends GET requests and rejects all other methods.
+func (c *readOnlyClient) Do(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return nil, fmt.Errorf("refusing %s %s: the provider is configured with read_only = true", req.Method, req.URL.Path)
+	}
+
+	return c.client.Do(req)
+}
+
+// endpointTransport rewrites requests issued by the Instatus client so they
+// target a custom API endpoint instead of api.instatus.com.
+type endpointTransport struct {
+	endpoint  *url.URL
+	transport http.RoundTripper
+}
+
+// RoundTrip replaces the scheme, host and base path of the request.
+func (t *endpointTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.endpoint.Scheme
+	req.URL.Host = t.endpoint.Host
+	req.URL.Path = strings.TrimSuffix(t.endpoint.Path, "/") + strings.TrimPrefix(req.URL.Path, "/v1")
+	req.Host = ""
+
+	return t.transport.RoundTrip(req)
+}
+
+// userAgentTransport identifies the provider in the User-Agent header.
+type userAgentTransport struct {
+	userAgent string
+	transport http.RoundTripper
+}
+
+// RoundTrip sets the User-Agent header of the request.
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.userAgent)
+
+	return t.transport.RoundTrip(req)
+}
+
+// rateLimitTransport throttles outgoing requests, retries included, so large
+// plans stay under the Instatus API rate limits.
+type rateLimitTransport struct {
+	limiter   *rate.Limiter
+	transport http.RoundTripper
+}
+
+// RoundTrip waits for the rate limiter before sending the request.
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	return t.transport.RoundTrip(req)
+}
+
+// newTLSConfig builds the TLS configuration used to reach the Instatus API,
+// trusting the certificates of caCertFile in addition to the system ones.
+func newTLSConfig(caCertFile string, insecureSkipVerify bool) (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: insecureSkipVerify,
+	}
+
+	if caCertFile != "" {
+		pem, err := os.ReadFile(caCertFile)
+		if err != nil {
+			return nil, err
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no PEM encoded certificate found in %s", caCertFile)
+		}
+		config.RootCAs = pool
+	}
+
+	return config, nil
+}
+
+// semaphoreTransport limits the number of requests in flight at once,
+// independently of Terraform's own parallelism.
+type semaphoreTransport struct {
+	slots     chan struct{}
+	transport http.RoundTripper
+}
+
+// RoundTrip waits for a free slot before sending the request.
+func (t *semaphoreTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	select {
+	case t.slots <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+	defer func() { <-t.slots }()
+
+	return t.transport.RoundTrip(req)
+}
+
+// parseAPIEndpoint validates a configured API endpoint.
+func parseAPIEndpoint(endpoint string) (*url.URL, error) {
+	return parseURL(endpoint, "http", "https")
+}
+
+// parseProxyURL validates a configured proxy URL.
+func parseProxyURL(proxy string) (*url.URL, error) {
+	return parseURL(proxy, "http", "https", "socks5")
+}
+
+// parseURL parses an absolute URL and checks its scheme.
+func parseURL(rawURL string, schemes ...string) (*url.URL, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(schemes, u.Scheme) || u.Host == "" {
+		return nil, fmt.Errorf("%q must be an absolute URL with one of the schemes: %s", rawURL, strings.Join(schemes, ", "))
+	}
+
+	return u, nil
+}
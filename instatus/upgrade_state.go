@@ -0,0 +1,65 @@
+package instatus
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// upgradeStateToSchema upgrades a prior state to the current schema of a
+// resource when the newer versions only added attributes or defaults. Added
+// attributes are left null, unless defaults gives them a value, which also
+// replaces the prior null values of existing attributes.
+func upgradeStateToSchema(ctx context.Context, current schema.Schema, defaults map[string]tftypes.Value, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	stateType := current.Type().TerraformType(ctx)
+
+	rawState, err := rawStateToSchema(stateType, defaults, req.RawState)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Upgrade Resource State",
+			"The prior state of the resource could not be read: "+err.Error(),
+		)
+		return
+	}
+
+	dynamicValue, err := tfprotov6.NewDynamicValue(stateType, rawState)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Upgrade Resource State",
+			"The upgraded state of the resource could not be encoded: "+err.Error(),
+		)
+		return
+	}
+
+	resp.DynamicValue = &dynamicValue
+}
+
+// rawStateToSchema decodes a raw state written with another schema into the
+// given state type, ignoring the attributes the type lacks and filling the
+// top-level null attributes found in defaults.
+func rawStateToSchema(stateType tftypes.Type, defaults map[string]tftypes.Value, raw *tfprotov6.RawState) (tftypes.Value, error) {
+	rawState, err := raw.UnmarshalWithOpts(stateType, tfprotov6.UnmarshalOpts{
+		ValueFromJSONOpts: tftypes.ValueFromJSONOpts{
+			IgnoreUndefinedAttributes: true,
+		},
+	})
+	if err != nil {
+		return rawState, err
+	}
+
+	return tftypes.Transform(rawState, func(p *tftypes.AttributePath, value tftypes.Value) (tftypes.Value, error) {
+		steps := p.Steps()
+		if len(steps) != 1 || !value.IsNull() {
+			return value, nil
+		}
+		if name, ok := steps[0].(tftypes.AttributeName); ok {
+			if defaultValue, ok := defaults[string(name)]; ok {
+				return defaultValue, nil
+			}
+		}
+		return value, nil
+	})
+}
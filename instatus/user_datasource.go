@@ -3,7 +3,6 @@ package instatus
 import (
 	"context"
 
-	is "github.com/brunoscota/instatus-client-go"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -22,7 +21,7 @@ func NewUserDataSource() datasource.DataSource {
 
 // userDataSource is the data source implementation.
 type userDataSource struct {
-	client *is.Client
+	provider *instatusProviderData
 }
 
 // userDataSourceModel maps the data source schema data.
@@ -74,18 +73,21 @@ func (d *userDataSource) Configure(_ context.Context, req datasource.ConfigureRe
 		return
 	}
 
-	d.client = req.ProviderData.(*is.Client)
+	d.provider = req.ProviderData.(*instatusProviderData)
 }
 
 // Read refreshes the Terraform state with the latest data.
 func (d *userDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var state userDataSourceModel
 
-	user, err := d.client.GetUser()
+	user, err := d.provider.Client(ctx).GetUser()
+	resp.Diagnostics.Append(d.provider.quota.Diagnostics()...)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addAPIError(
+			&resp.Diagnostics,
 			"Unable to Read Instatus User",
-			err.Error(),
+			"Could not read Instatus user",
+			err,
 		)
 		return
 	}
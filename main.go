@@ -2,16 +2,48 @@ package main
 
 import (
 	"context"
+	"io"
+	"log"
 	"terraform-provider-instatus/instatus"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
+	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
 )
 
 // Provider documentation generation.
 //go:generate go run github.com/hashicorp/terraform-plugin-docs/cmd/tfplugindocs generate --provider-name instatus
 
+// version is set by goreleaser at build time.
+var version string = "dev"
+
 func main() {
-	providerserver.Serve(context.Background(), instatus.New, providerserver.ServeOpts{
-		Address: "registry.terraform.io/brunoscota/instatus",
-	})
+	ctx := context.Background()
+
+	// Nested attributes, used across the resource schemas, require
+	// protocol version 6 (Terraform 1.0 and later). Serving through a mux
+	// server lets resources written with other SDKs join this binary.
+	provider := instatus.New(version)()
+	providers := []func() tfprotov6.ProviderServer{
+		providerserver.NewProtocol6(provider),
+	}
+
+	muxServer, err := tf6muxserver.NewMuxServer(ctx, providers...)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = tf6server.Serve("registry.terraform.io/brunoscota/instatus", muxServer.ProviderServer)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Serve returns once Terraform stops the provider, which is when the
+	// metrics of the run are complete.
+	if closer, ok := provider.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			log.Fatal(err)
+		}
+	}
 }